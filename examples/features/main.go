@@ -96,6 +96,9 @@ func NewModel() Model {
 	keys.RowDown.SetKeys("j", "down", "s")
 	keys.RowUp.SetKeys("k", "up", "w")
 
+	// s is used to move down instead, so it shouldn't also sort
+	keys.SortToggle.Unbind()
+
 	model := Model{
 		// Throw features in... the point is not to look good, it's just reference!
 		tableModel: table.New(columns).
@@ -0,0 +1,168 @@
+package table
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Shown in the footer while rows are being filtered and sorted in the
+// background
+const asyncFilterSortIndicator = "filtering…"
+
+// visibleRowsMsg carries rows that were filtered and sorted in the background
+// back to the table that asked for them.
+type visibleRowsMsg struct {
+	tableID int
+	version int
+
+	rows          []Row
+	sourceIndices []int
+}
+
+// WithAsyncFilterSort sets whether filtering and sorting caused by user input
+// is done in the background, so that typing in the filter stays responsive
+// with very large tables.  While enabled, Update returns a command that filters
+// and sorts the rows, and the table keeps showing the previous rows with a
+// filtering indicator in the footer until the command's message is passed back
+// to Update.  Work for a filter that has since changed is cancelled.  Any
+// custom filter or sort functions must be safe to call from another goroutine.
+// Changes made with other functions, such as WithFilterInputValue, are still
+// applied immediately.
+func (m Model) WithAsyncFilterSort(enabled bool) Model {
+	m.asyncFilterSort = enabled
+
+	if !enabled {
+		m.cancelAsyncFilterSort()
+	}
+
+	return m
+}
+
+// canShowOutdatedRows returns true if the cached rows can be shown while new
+// ones are filtered and sorted in the background.
+func (m *Model) canShowOutdatedRows() bool {
+	return m.asyncFilterSort &&
+		m.dataSource == nil &&
+		m.visibleRowChanges.rowsUnchanged &&
+		m.visibleRowSourceIndexCache != nil
+}
+
+// isWaitingForVisibleRows returns true if the cached rows are out of date and
+// are being shown until new rows arrive from the background.
+func (m *Model) isWaitingForVisibleRows() bool {
+	return !m.visibleRowCacheUpdated && (m.asyncPending || m.asyncInUpdate) && m.canShowOutdatedRows()
+}
+
+// refreshWaitingVisibleRows reads the rows that are being shown again from the
+// source rows, such as to show a change in selection before the new rows
+// arrive.
+func (m *Model) refreshWaitingVisibleRows() {
+	rows := make([]Row, len(m.visibleRowCache))
+
+	for i, sourceIndex := range m.visibleRowSourceIndexCache {
+		rows[i] = m.rows[sourceIndex]
+	}
+
+	m.visibleRowCache = rows
+}
+
+func (m *Model) cancelAsyncFilterSort() {
+	if m.asyncCancel != nil {
+		m.asyncCancel()
+	}
+
+	m.asyncCancel = nil
+	m.asyncPending = false
+}
+
+// startAsyncFilterSort returns a command that filters and sorts the rows in
+// the background if they're out of date, cancelling any earlier work that
+// hasn't finished.  Returns nil if there's nothing to do.
+func (m *Model) startAsyncFilterSort() tea.Cmd {
+	if m.visibleRowCacheUpdated || !m.canShowOutdatedRows() {
+		return nil
+	}
+
+	if m.asyncPending && m.asyncVersion == m.visibleRowsVersion {
+		return nil
+	}
+
+	m.cancelAsyncFilterSort()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m.asyncCancel = cancel
+	m.asyncPending = true
+	m.asyncVersion = m.visibleRowsVersion
+
+	// Work from a copy so that nothing changes underneath it, including the
+	// text input which may reuse its memory as the user types
+	snapshot := *m
+	snapshot.filterTextInput.SetValue(m.filterTextInput.Value())
+
+	return func() tea.Msg {
+		rows, sourceIndices, err := snapshot.buildVisibleRows(ctx)
+
+		if err != nil {
+			return nil
+		}
+
+		return visibleRowsMsg{
+			tableID:       snapshot.tableID,
+			version:       snapshot.asyncVersion,
+			rows:          rows,
+			sourceIndices: sourceIndices,
+		}
+	}
+}
+
+// receiveVisibleRows shows rows that were filtered and sorted in the
+// background, unless they've become out of date since they were asked for.
+func (m Model) receiveVisibleRows(msg visibleRowsMsg) (Model, tea.Cmd) {
+	if msg.tableID != m.tableID || !m.asyncPending || msg.version != m.asyncVersion {
+		return m, nil
+	}
+
+	// The row the user sees highlighted, which may have moved since the work
+	// started
+	highlightedID := m.highlightedRowID()
+
+	m.cancelAsyncFilterSort()
+
+	// Something changed without going through Update, so start over
+	if msg.version != m.visibleRowsVersion {
+		return m, m.startAsyncFilterSort()
+	}
+
+	m.setVisibleRowCache(msg.rows, msg.sourceIndices)
+
+	if m.rowCursorIndex >= len(msg.rows) {
+		m.rowCursorIndex = max(len(msg.rows)-1, 0)
+	}
+
+	m.currentPage = m.expectedPageForRowIndex(m.rowCursorIndex)
+	m.highlightRowByID(highlightedID)
+
+	if m.following {
+		m.pinToNewestRow()
+	}
+
+	m.updateVerticalScroll()
+
+	return m, nil
+}
+
+// batchCmds combines commands, returning nil if there are none.
+func batchCmds(first, second tea.Cmd) tea.Cmd {
+	switch {
+	case first == nil:
+		return second
+
+	case second == nil:
+		return first
+
+	default:
+		return tea.Batch(first, second)
+	}
+}
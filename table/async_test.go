@@ -0,0 +1,187 @@
+package table
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func asyncTestModel() Model {
+	// A blinking cursor would return its own commands as the user types
+	filterInput := textinput.New()
+	filterInput.Prompt = "/"
+	filterInput.SetCursorMode(textinput.CursorStatic)
+
+	rows := []Row{}
+
+	for i := 0; i < 10; i++ {
+		rows = append(rows, NewRow(RowData{
+			"name": fmt.Sprintf("item %d", i),
+			"type": []string{"fire", "water"}[i%2],
+		}))
+	}
+
+	model := New([]Column{
+		NewColumn("name", "Name", 10).WithFiltered(true),
+		NewColumn("type", "Type", 10).WithFiltered(true),
+	}).WithRows(rows).
+		Filtered(true).
+		Focused(true).
+		WithFilterInput(filterInput).
+		WithAsyncFilterSort(true)
+
+	// Rows are only filtered in the background once there are rows to show in
+	// the meantime, which is normally after the first render
+	model.GetVisibleRows()
+
+	return model
+}
+
+func TestAsyncFilterShowsOldRowsUntilDone(t *testing.T) {
+	model := asyncTestModel()
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	model, cmd := model.Update(runesMsg("w"))
+
+	assert.NotNil(t, cmd)
+	assert.Len(t, model.GetVisibleRows(), 10, "Should keep showing the old rows")
+	assert.Contains(t, model.renderFooter(40, false), "filtering…")
+
+	msg := cmd()
+
+	assert.IsType(t, visibleRowsMsg{}, msg)
+
+	model, cmd = model.Update(msg)
+
+	assert.Nil(t, cmd)
+	assert.Len(t, model.GetVisibleRows(), 5)
+	assert.NotContains(t, model.renderFooter(40, false), "filtering…")
+}
+
+func TestAsyncFilterDropsOutdatedRows(t *testing.T) {
+	model := asyncTestModel()
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	model, firstCmd := model.Update(runesMsg("9"))
+
+	// Build the rows before the filter changes again, as if it was too late to
+	// cancel it
+	firstMsg := firstCmd()
+
+	model, secondCmd := model.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	model, thirdCmd := model.Update(runesMsg("8"))
+
+	assert.Nil(t, secondCmd(), "Should have been cancelled")
+
+	model, _ = model.Update(firstMsg)
+
+	assert.Len(t, model.GetVisibleRows(), 10, "Should ignore rows for an old filter")
+
+	model, _ = model.Update(thirdCmd())
+
+	assert.Equal(t, []string{"item 8"}, visibleNames(model))
+}
+
+func TestAsyncSortToggle(t *testing.T) {
+	model := asyncTestModel().WithRows([]Row{
+		NewRow(RowData{"name": "b"}),
+		NewRow(RowData{"name": "a"}),
+	})
+
+	model.GetVisibleRows()
+
+	model, cmd := model.WithCellCursor(true).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+
+	assert.Equal(t, []string{"b", "a"}, visibleNames(model))
+
+	model, _ = model.Update(cmd())
+
+	assert.Equal(t, []string{"a", "b"}, visibleNames(model))
+}
+
+func TestAsyncSortKeepsHighlightedRow(t *testing.T) {
+	rows := []Row{}
+
+	for n := 0; n < 10; n++ {
+		rows = append(rows, NewRow(RowData{"name": fmt.Sprintf("item %d", n)}).WithID(n))
+	}
+
+	model := asyncTestModel().
+		WithRows(rows).
+		WithCellCursor(true).
+		SortByAsc("name").
+		HighlightRowByID(5)
+
+	model.GetVisibleRows()
+
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	model, _ = model.Update(cmd())
+
+	assert.Equal(t, 5, model.HighlightedRow().ID())
+	assert.Equal(t, 4, model.GetHighlightedRowIndex())
+
+	// Back to unsorted, and the user moves while the old rows are still shown
+	model, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(cmd())
+
+	assert.Equal(t, 4, model.HighlightedRow().ID())
+	assert.Equal(t, 4, model.GetHighlightedRowIndex())
+}
+
+func TestAsyncIgnoresOtherTables(t *testing.T) {
+	model := asyncTestModel()
+	other := asyncTestModel()
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	model, cmd := model.Update(runesMsg("w"))
+	msg := cmd()
+
+	other, _ = other.Update(msg)
+
+	assert.Len(t, other.GetVisibleRows(), 10)
+
+	model, _ = model.Update(msg)
+
+	assert.Len(t, model.GetVisibleRows(), 5)
+}
+
+func TestAsyncSelectionWhileFiltering(t *testing.T) {
+	model := asyncTestModel().SelectableRows(true)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	model, _ = model.Update(runesMsg("w"))
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	assert.True(t, model.GetVisibleRows()[0].selected, "Should show the selection right away")
+
+	model, _ = model.Update(cmd())
+
+	assert.Len(t, model.GetVisibleRows(), 5)
+	assert.Len(t, model.SelectedRows(), 1)
+	assert.Equal(t, "item 0", model.SelectedRows()[0].Data["name"])
+}
+
+func TestAsyncOptionsApplyImmediately(t *testing.T) {
+	model := asyncTestModel()
+
+	model.GetVisibleRows()
+
+	model = model.WithFilterInputValue("fire")
+
+	assert.Len(t, model.GetVisibleRows(), 5)
+}
+
+func TestAsyncDisabledByDefault(t *testing.T) {
+	model := asyncTestModel().WithAsyncFilterSort(false)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	model, cmd := model.Update(runesMsg("w"))
+
+	assert.Nil(t, cmd)
+	assert.Len(t, model.GetVisibleRows(), 5)
+}
@@ -98,3 +98,70 @@ func BenchmarkPlainTableViews(b *testing.B) {
 		})
 	}
 }
+
+var benchRows []Row
+
+func benchSortRows(numRows int) []Row {
+	rows := make([]Row, 0, numRows)
+
+	for i := 0; i < numRows; i++ {
+		rows = append(rows, NewRow(RowData{
+			"group": fmt.Sprintf("group-%d", i%10),
+			"score": (i * 7919) % 1000,
+			"name":  fmt.Sprintf("name-%d", (i*104729)%numRows),
+		}))
+	}
+
+	return rows
+}
+
+func BenchmarkGetSortedRows(b *testing.B) {
+	sizes := []int{1000, 100000}
+
+	sortOrders := []struct {
+		name      string
+		sortOrder []SortColumn
+	}{
+		{
+			name: "1 column",
+			sortOrder: []SortColumn{
+				{ColumnKey: "name", Direction: SortDirectionAsc},
+			},
+		},
+		{
+			name: "3 columns",
+			sortOrder: []SortColumn{
+				{ColumnKey: "name", Direction: SortDirectionAsc},
+				{ColumnKey: "score", Direction: SortDirectionDesc},
+				{ColumnKey: "group", Direction: SortDirectionAsc},
+			},
+		},
+	}
+
+	for _, size := range sizes {
+		rows := benchSortRows(size)
+
+		for _, sortOrder := range sortOrders {
+			b.Run(fmt.Sprintf("%s %d rows", sortOrder.name, size), func(b *testing.B) {
+				for n := 0; n < b.N; n++ {
+					benchRows = getSortedRows(nil, sortOrder.sortOrder, sortOptions{}, rows)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkGetSortedRowsNatural(b *testing.B) {
+	rows := benchSortRows(100000)
+	sortOrder := []SortColumn{
+		{ColumnKey: "name", Direction: SortDirectionAsc},
+		{ColumnKey: "group", Direction: SortDirectionAsc},
+	}
+	options := sortOptions{natural: true, caseInsensitive: true}
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		benchRows = getSortedRows(nil, sortOrder, options, rows)
+	}
+}
@@ -358,7 +358,7 @@ func (b *borderStyleRow) inherit(s lipgloss.Style) {
 //
 //nolint:nestif
 func (m Model) styleHeaders() borderStyleRow {
-	hasRows := len(m.GetVisibleRows()) > 0 || m.calculatePadding(0) > 0
+	hasRows := m.visibleRowCount() > 0 || m.calculatePadding(0) > 0
 	singleColumn := len(m.columns) == 1
 	styles := borderStyleRow{}
 
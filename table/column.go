@@ -13,11 +13,23 @@ type Column struct {
 	flexFactor int
 
 	filterable bool
+	sortable   bool
 	style      lipgloss.Style
 
 	fmtString string
+
+	editable      bool
+	editValidator CellEditValidator
+
+	sortFunc SortFunc
 }
 
+// SortFunc compares two values from a column for sorting.  It returns a
+// negative number if a comes before b, a positive number if a comes after b,
+// or 0 if they are equal.  The values are the data in each row, with any
+// StyledCell unwrapped, or nil if the row has no data for the column.
+type SortFunc func(a, b interface{}) int
+
 // NewColumn creates a new fixed-width column with the given information.
 func NewColumn(key, title string, width int) Column {
 	return Column{
@@ -26,6 +38,7 @@ func NewColumn(key, title string, width int) Column {
 		width: width,
 
 		filterable: false,
+		sortable:   true,
 	}
 }
 
@@ -42,6 +55,8 @@ func NewFlexColumn(key, title string, flexFactor int) Column {
 		title: title,
 
 		flexFactor: max(flexFactor, 1),
+
+		sortable: true,
 	}
 }
 
@@ -60,6 +75,37 @@ func (c Column) WithFiltered(filterable bool) Column {
 	return c
 }
 
+// WithSortable sets whether the user can interactively sort by the column,
+// either with the sort keys or by clicking the header.  Columns are sortable
+// by default.  This does not prevent sorting in code with SortByAsc and similar.
+func (c Column) WithSortable(sortable bool) Column {
+	c.sortable = sortable
+
+	return c
+}
+
+// WithSortFunc sets how the column's data is compared when sorting, for data
+// that doesn't sort correctly as numbers or text, such as dates, versions, or
+// sizes like "10 KB".  The function always compares in ascending order, and is
+// reversed when sorting in descending order.
+func (c Column) WithSortFunc(sortFunc SortFunc) Column {
+	c.sortFunc = sortFunc
+
+	return c
+}
+
+// WithEditable allows the user to edit cells in the column.  The validator is
+// called with the text the user entered and returns the value to store, or an
+// error to show to the user instead.  If the validator is nil, any text is
+// accepted and stored as a string.  Cells can only be edited when the cell
+// cursor is enabled with WithCellCursor.
+func (c Column) WithEditable(validator CellEditValidator) Column {
+	c.editable = true
+	c.editValidator = validator
+
+	return c
+}
+
 // WithFormatString sets the format string used by fmt.Sprintf to display the data.
 // If not set, the default is "%v" for all data types.  Intended mainly for
 // numeric formatting.
@@ -107,6 +153,16 @@ func (c Column) Filterable() bool {
 	return c.filterable
 }
 
+// Sortable returns whether the column can be interactively sorted by the user.
+func (c Column) Sortable() bool {
+	return c.sortable
+}
+
+// Editable returns whether the user can edit cells in the column.
+func (c Column) Editable() bool {
+	return c.editable
+}
+
 // Style returns the style of the column.
 func (c Column) Style() lipgloss.Style {
 	return c.style
@@ -168,6 +168,13 @@ func TestColumnFilterable(t *testing.T) {
 	}
 }
 
+func TestColumnSortable(t *testing.T) {
+	assert.True(t, NewColumn("key", "title", 10).Sortable(), "Should be sortable by default")
+	assert.True(t, NewFlexColumn("key", "title", 1).Sortable(), "Should be sortable by default")
+	assert.False(t, NewColumn("key", "title", 10).WithSortable(false).Sortable())
+	assert.True(t, NewColumn("key", "title", 10).WithSortable(false).WithSortable(true).Sortable())
+}
+
 func TestColumnStyle(t *testing.T) {
 	width := 10
 	tests := []struct {
@@ -0,0 +1,253 @@
+package table
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// WithColumnFilterRow sets whether a row of filter inputs is shown under the
+// header, one per filterable column, as an alternative to the single filter in
+// the footer.  Each input only filters its own column, and all of them must
+// match along with any footer filter.  The Filter key focuses the inputs, the
+// ColumnFilterNext and ColumnFilterPrevious keys move between them, and the
+// FilterBlur key stops typing.  Values use the same syntax as the footer
+// filter, without column names, such as "fire", "-water", or ">50".  Filtering
+// must be enabled with Filtered, and the row is hidden with the header.
+func (m Model) WithColumnFilterRow(enabled bool) Model {
+	m.columnFilterRow = enabled
+	m.invalidateFilterAndSort()
+
+	if !enabled {
+		m.blurColumnFilter()
+	}
+
+	if m.hasHeightConstraint() {
+		m.recalculateHeight()
+	}
+
+	return m
+}
+
+// WithColumnFilterValue sets the filter for a single column as if the user had
+// typed it into the column's filter input.  An empty value clears the filter.
+func (m Model) WithColumnFilterValue(columnKey string, value string) Model {
+	if m.columnFilterValues[columnKey] != value {
+		m.pageFirst()
+	}
+
+	m.setColumnFilterValue(columnKey, value)
+
+	if m.columnFilterFocused() && m.columns[m.columnFilterFocusIndex].key == columnKey {
+		m.columnFilterInput.SetValue(value)
+	}
+
+	return m
+}
+
+// setColumnFilterValue copies the values so that older models are unaffected.
+func (m *Model) setColumnFilterValue(columnKey string, value string) {
+	values := make(map[string]string, len(m.columnFilterValues)+1)
+
+	for k, v := range m.columnFilterValues {
+		values[k] = v
+	}
+
+	if value == "" {
+		delete(values, columnKey)
+	} else {
+		values[columnKey] = value
+	}
+
+	m.columnFilterValues = values
+	m.invalidateFilteredRows()
+}
+
+func (m *Model) clearColumnFilters() {
+	m.columnFilterValues = nil
+	m.columnFilterInput.Reset()
+	m.invalidateFilterAndSort()
+}
+
+func (m *Model) hasColumnFilters() bool {
+	return m.filtered && m.columnFilterRow && len(m.columnFilterValues) > 0
+}
+
+func (m Model) isColumnFilterRowVisible() bool {
+	return m.filtered && m.columnFilterRow && m.headerVisible
+}
+
+func (m Model) columnFilterFocused() bool {
+	return m.columnFilterFocusIndex >= 0 && m.columnFilterFocusIndex < len(m.columns)
+}
+
+// columnFilterIndexFrom returns the index of the first filterable column found
+// by stepping from the given index, wrapping around, or -1 if there is none.
+func (m *Model) columnFilterIndexFrom(start, step int) int {
+	numColumns := len(m.columns)
+
+	for i := 0; i < numColumns; i++ {
+		index := ((start+i*step)%numColumns + numColumns) % numColumns
+
+		if m.columns[index].filterable {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// startColumnFilterTyping focuses the filter input of the highlighted cell's
+// column if it's filterable, otherwise the first filterable column.  Returns
+// false if there are no filterable columns.
+func (m *Model) startColumnFilterTyping() bool {
+	index := -1
+
+	if m.cellCursor && m.columnCursorIndex < len(m.columns) && m.columns[m.columnCursorIndex].filterable {
+		index = m.columnCursorIndex
+	} else if len(m.columns) > 0 {
+		index = m.columnFilterIndexFrom(0, 1)
+	}
+
+	if index < 0 {
+		return false
+	}
+
+	m.focusColumnFilter(index)
+
+	return true
+}
+
+func (m *Model) focusColumnFilter(columnIndex int) {
+	column := m.columns[columnIndex]
+
+	m.columnFilterFocusIndex = columnIndex
+	m.columnFilterInput.SetValue(m.columnFilterValues[column.key])
+
+	// Leave room for the cursor at the end
+	m.columnFilterInput.Width = max(column.width-1, 1)
+	m.columnFilterInput.Focus()
+	m.columnFilterInput.CursorEnd()
+
+	m.scrollToColumn(columnIndex)
+}
+
+func (m *Model) moveColumnFilterFocus(step int) {
+	index := m.columnFilterIndexFrom(m.columnFilterFocusIndex+step, step)
+
+	if index >= 0 {
+		m.focusColumnFilter(index)
+	}
+}
+
+func (m *Model) blurColumnFilter() {
+	m.columnFilterFocusIndex = -1
+	m.columnFilterInput.Blur()
+}
+
+func (m Model) updateColumnFilterInput(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keyMap.ColumnFilterNext):
+			m.moveColumnFilterFocus(1)
+
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.ColumnFilterPrevious):
+			m.moveColumnFilterFocus(-1)
+
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.FilterBlur):
+			m.blurColumnFilter()
+
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+
+	m.columnFilterInput, cmd = m.columnFilterInput.Update(msg)
+
+	columnKey := m.columns[m.columnFilterFocusIndex].key
+
+	if m.columnFilterInput.Value() != m.columnFilterValues[columnKey] {
+		m.setColumnFilterValue(columnKey, m.columnFilterInput.Value())
+		m.pageFirst()
+	}
+
+	return m, cmd
+}
+
+// columnFilterText returns what to show under the header of the given column.
+func (m Model) columnFilterText(column Column) string {
+	if !column.filterable {
+		return ""
+	}
+
+	if m.columnFilterFocused() && m.columns[m.columnFilterFocusIndex].key == column.key {
+		return m.columnFilterInput.View()
+	}
+
+	return limitStr(m.columnFilterValues[column.key], column.width)
+}
+
+func (m Model) getColumnFilteredRows(rows []Row) []Row {
+	if !m.hasColumnFilters() {
+		return rows
+	}
+
+	terms := []filterTerm{}
+
+	for _, column := range m.columns {
+		if !column.filterable {
+			continue
+		}
+
+		if value, ok := m.columnFilterValues[column.key]; ok {
+			terms = append(terms, parseColumnFilter(column.key, value)...)
+		}
+	}
+
+	filteredRows := make([]Row, 0)
+
+	for _, row := range rows {
+		if isRowMatchedByTerms(m.columns, row, terms) {
+			filteredRows = append(filteredRows, row)
+		}
+	}
+
+	return filteredRows
+}
+
+// parseColumnFilter splits the value of a column's filter input into terms for
+// that column.  As in the footer filter, a term may be negated with a leading -
+// unless it's a negative number, may start with a comparison operator such as
+// >= to compare numbers, or be quoted to match a phrase with spaces.
+func parseColumnFilter(columnKey string, value string) []filterTerm {
+	terms := []filterTerm{}
+
+	for _, raw := range splitFilterTerms(value) {
+		term := filterTerm{
+			columnKey: columnKey,
+		}
+
+		raw, term.negated = parseFilterNegation(raw)
+
+		for _, op := range filterOperatorSymbols {
+			if strings.HasPrefix(raw, op.symbol) {
+				term.operator = op.operator
+				raw = raw[len(op.symbol):]
+
+				break
+			}
+		}
+
+		term.value = strings.ToLower(strings.ReplaceAll(raw, `"`, ""))
+
+		terms = append(terms, term)
+	}
+
+	return terms
+}
@@ -0,0 +1,212 @@
+package table
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func columnFilterTestModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 10).WithFiltered(true),
+		NewColumn("type", "Type", 8).WithFiltered(true),
+		NewColumn("hp", "HP", 4).WithFiltered(true),
+		NewColumn("notes", "Notes", 6),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "Pikachu", "type": "Electric", "hp": 35, "notes": "yellow"}),
+		NewRow(RowData{"name": "Charmander", "type": "Fire", "hp": 39, "notes": "orange"}),
+		NewRow(RowData{"name": "Vulpix", "type": "Fire", "hp": 38, "notes": "red"}),
+	}).Filtered(true).Focused(true).WithColumnFilterRow(true)
+}
+
+func typeKeys(model Model, msgs ...tea.Msg) Model {
+	for _, msg := range msgs {
+		model, _ = model.Update(msg)
+	}
+
+	return model
+}
+
+func runesMsg(str string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(str)}
+}
+
+func TestColumnFilterRowRenders(t *testing.T) {
+	model := columnFilterTestModel().
+		WithColumnFilterValue("type", "fi").
+		WithFooterVisibility(false)
+
+	const expectedTable = `┏━━━━━━━━━━┳━━━━━━━━┳━━━━┳━━━━━━┓
+┃      Name┃    Type┃  HP┃ Notes┃
+┃          ┃      fi┃    ┃      ┃
+┣━━━━━━━━━━╋━━━━━━━━╋━━━━╋━━━━━━┫
+┃Charmander┃    Fire┃  39┃orange┃
+┃    Vulpix┃    Fire┃  38┃   red┃
+┗━━━━━━━━━━┻━━━━━━━━┻━━━━┻━━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+
+	// The row is hidden along with the header
+	model = model.WithHeaderVisibility(false)
+
+	assert.NotContains(t, model.View(), "fi")
+}
+
+func TestColumnFilterRowTabNavigation(t *testing.T) {
+	model := columnFilterTestModel()
+
+	model = typeKeys(model, runesMsg("/"))
+
+	assert.True(t, model.GetIsFilterInputFocused())
+	assert.Equal(t, []UserEvent{UserEventFilterInputFocused{}}, model.GetLastUpdateUserEvents())
+	assert.False(t, model.filterTextInput.Focused(), "Should not focus the footer filter")
+
+	model = typeKeys(model, tea.KeyMsg{Type: tea.KeyTab}, runesMsg("f"), runesMsg("i"))
+
+	assert.Equal(t, map[string]string{"type": "fi"}, model.GetColumnFilterValues())
+	assert.Len(t, model.GetVisibleRows(), 2)
+
+	model = typeKeys(model, tea.KeyMsg{Type: tea.KeyTab}, runesMsg(">"), runesMsg("3"), runesMsg("8"))
+
+	assert.Equal(t, map[string]string{"type": "fi", "hp": ">38"}, model.GetColumnFilterValues())
+	assert.Equal(t, []string{"Charmander"}, visibleNames(model))
+
+	// Wraps around to the first filterable column, skipping the notes column
+	model = typeKeys(model, tea.KeyMsg{Type: tea.KeyTab}, runesMsg("x"))
+
+	assert.Equal(t, "x", model.GetColumnFilterValues()["name"])
+	assert.Empty(t, model.GetVisibleRows())
+
+	model = typeKeys(model, tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyShiftTab}, tea.KeyMsg{Type: tea.KeyBackspace})
+
+	assert.Equal(t, map[string]string{"type": "fi", "hp": ">3"}, model.GetColumnFilterValues())
+
+	model = typeKeys(model, tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.False(t, model.GetIsFilterInputFocused())
+	assert.Equal(t, []UserEvent{UserEventFilterInputUnfocused{}}, model.GetLastUpdateUserEvents())
+	assert.Len(t, model.GetVisibleRows(), 2)
+
+	// Clearing the filter clears every column
+	model = typeKeys(model, tea.KeyMsg{Type: tea.KeyEsc})
+
+	assert.Empty(t, model.GetColumnFilterValues())
+	assert.Len(t, model.GetVisibleRows(), 3)
+}
+
+func TestColumnFilterRowCombinesWithFooterFilter(t *testing.T) {
+	model := columnFilterTestModel().
+		WithColumnFilterValue("type", "fire").
+		WithFilterInputValue("vul")
+
+	assert.Equal(t, []string{"Vulpix"}, visibleNames(model))
+	assert.True(t, model.GetIsFilterActive())
+
+	model = model.WithFilterInputValue("")
+
+	assert.True(t, model.GetIsFilterActive())
+	assert.Len(t, model.GetVisibleRows(), 2)
+
+	// Only applies while the row is enabled
+	model = model.WithColumnFilterRow(false)
+
+	assert.False(t, model.GetIsFilterActive())
+	assert.Len(t, model.GetVisibleRows(), 3)
+}
+
+func TestColumnFilterOnlyMatchesOwnColumn(t *testing.T) {
+	model := columnFilterTestModel().WithColumnFilterValue("name", "fire")
+
+	assert.Empty(t, model.GetVisibleRows())
+
+	model = model.WithColumnFilterValue("name", "-pika")
+
+	assert.Equal(t, []string{"Charmander", "Vulpix"}, visibleNames(model))
+}
+
+func TestColumnFilterNegativeNumberMatchesFooterFilter(t *testing.T) {
+	model := New([]Column{
+		NewColumn("name", "Name", 10).WithFiltered(true),
+		NewColumn("delta", "Delta", 5).WithFiltered(true),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "down", "delta": -5}),
+		NewRow(RowData{"name": "up", "delta": 7}),
+	}).Filtered(true).WithColumnFilterRow(true)
+
+	footer := model.WithFilterInputValue("-5")
+	column := model.WithColumnFilterValue("delta", "-5")
+
+	assert.Equal(t, []string{"down"}, visibleNames(footer))
+	assert.Equal(t, visibleNames(footer), visibleNames(column))
+
+	// Still negated when it isn't a number on its own
+	column = model.WithColumnFilterValue("delta", "--5")
+
+	assert.Equal(t, []string{"up"}, visibleNames(column))
+}
+
+func TestColumnFilterStartsAtCellCursor(t *testing.T) {
+	model := columnFilterTestModel().WithCellCursor(true)
+
+	model = typeKeys(model, tea.KeyMsg{Type: tea.KeyCtrlRight}, tea.KeyMsg{Type: tea.KeyCtrlRight}, runesMsg("/"), runesMsg("3"))
+
+	assert.Equal(t, map[string]string{"hp": "3"}, model.GetColumnFilterValues())
+}
+
+func TestColumnFilterScrollsToFocusedColumn(t *testing.T) {
+	model := columnFilterTestModel().WithMaxTotalWidth(20).WithFooterVisibility(false)
+
+	model = typeKeys(model, runesMsg("/"), tea.KeyMsg{Type: tea.KeyTab}, tea.KeyMsg{Type: tea.KeyTab}, runesMsg("3"), runesMsg("9"))
+
+	const expectedTable = `┏━┳━━━━━━━━┳━━━━┳━━┓
+┃<┃    Type┃  HP┃ >┃
+┃ ┃        ┃ 39` + "\x1b[7m \x1b[0m" + `┃  ┃
+┣━╋━━━━━━━━╋━━━━╋━━┫
+┃<┃    Fire┃  39┃ >┃
+┗━┻━━━━━━━━┻━━━━┻━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+
+	// Back to the start
+	model = typeKeys(model, tea.KeyMsg{Type: tea.KeyTab})
+
+	assert.Contains(t, model.View(), "┃      Name┃")
+}
+
+func TestColumnFilterMouseClickFocuses(t *testing.T) {
+	model := columnFilterTestModel()
+
+	// Border, title, then the filter row
+	model = typeKeys(model, tea.MouseMsg{X: 14, Y: 2, Type: tea.MouseLeft}, runesMsg("e"))
+
+	assert.Equal(t, map[string]string{"type": "e"}, model.GetColumnFilterValues())
+	assert.Empty(t, model.GetColumnSorting(), "Should not sort")
+
+	model = typeKeys(model, tea.KeyMsg{Type: tea.KeyEsc}, tea.MouseMsg{X: 14, Y: 1, Type: tea.MouseLeft})
+
+	assert.Equal(t, []SortColumn{{ColumnKey: "type", Direction: SortDirectionAsc}}, model.GetColumnSorting())
+}
+
+func TestColumnFilterRowHeight(t *testing.T) {
+	model := columnFilterTestModel().WithMinimumHeight(12)
+
+	assert.Equal(t, 12, lipgloss.Height(model.View()))
+}
+
+func TestColumnFilterQueryPushdown(t *testing.T) {
+	source := testQueryableDataSource{
+		testDataSource: newTestDataSource(100),
+		lastQuery:      &DataSourceQuery{},
+	}
+
+	model := dataSourceTestModel(source).
+		Filtered(true).
+		WithColumnFilterRow(true).
+		WithColumnFilterValue("id", "5")
+
+	model.GetVisibleRows()
+
+	assert.Equal(t, map[string]string{"id": "5"}, source.lastQuery.ColumnFilters)
+}
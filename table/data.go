@@ -1,6 +1,15 @@
 package table
 
-import "time"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+)
 
 // This is just a bunch of data type checks, so... no linting here
 //
@@ -63,3 +72,220 @@ func asNumber(data interface{}) (float64, bool) {
 
 	return float64(intVal), isInt
 }
+
+type sortValueKind int
+
+const (
+	sortValueMissing sortValueKind = iota
+	sortValueNumber
+	sortValueTime
+	sortValueText
+)
+
+// sortValue is a cell's data converted to what it should be sorted by.
+type sortValue struct {
+	kind sortValueKind
+
+	number float64
+
+	// Only set for numbers that may not fit in a float64, such as *big.Int
+	bigNumber *big.Float
+
+	time time.Time
+
+	// The data after following any pointers, used to compare as text
+	value interface{}
+
+	// The value as text with any case folding already done, only set for text
+	// values so that it's not recomputed for every comparison
+	text string
+
+	// The original data with any StyledCell unwrapped, or nil if missing
+	data interface{}
+}
+
+// toSortValue converts data to the value it should be sorted by.  Pointers are
+// followed, and nil pointers or nil values such as an invalid sql.NullString
+// are missing.
+func toSortValue(data interface{}, options sortOptions) sortValue {
+	value := readSortValue(data)
+
+	if value.kind == sortValueText {
+		value.text = value.foldedText(options)
+	}
+
+	return value
+}
+
+// This is just a bunch of data type checks, so... no linting here
+//
+//nolint:cyclop
+func readSortValue(data interface{}) sortValue {
+	if styled, isStyled := data.(StyledCell); isStyled {
+		data = styled.Data
+	}
+
+	if data == nil {
+		return sortValue{kind: sortValueMissing}
+	}
+
+	reflected := reflect.ValueOf(data)
+
+	if reflected.Kind() == reflect.Ptr && reflected.IsNil() {
+		return sortValue{kind: sortValueMissing}
+	}
+
+	value := sortValue{
+		kind:  sortValueText,
+		value: data,
+		data:  data,
+	}
+
+	switch val := data.(type) {
+	case string:
+		return value
+
+	case time.Time:
+		value.kind = sortValueTime
+		value.time = val
+
+		return value
+
+	case json.Number:
+		if parsed, ok := new(big.Float).SetString(val.String()); ok {
+			value.kind = sortValueNumber
+			value.bigNumber = parsed
+		}
+
+		return value
+
+	case *big.Int:
+		value.kind = sortValueNumber
+		value.bigNumber = new(big.Float).SetInt(val)
+
+		return value
+
+	case *big.Float:
+		value.kind = sortValueNumber
+		value.bigNumber = val
+
+		return value
+
+	case driver.Valuer:
+		// Such as sql.NullInt64, which is nil if not valid
+		if driverValue, err := val.Value(); err == nil {
+			followed := readSortValue(driverValue)
+			followed.data = data
+
+			return followed
+		}
+
+		return value
+	}
+
+	if number, isNumber := asNumber(data); isNumber {
+		value.kind = sortValueNumber
+		value.number = number
+
+		return value
+	}
+
+	if reflected.Kind() == reflect.Ptr {
+		followed := readSortValue(reflected.Elem().Interface())
+		followed.data = data
+
+		return followed
+	}
+
+	return value
+}
+
+// foldedText returns the value as text with any case folding from the options,
+// for comparing values that can't be compared any other way.  Missing values
+// are empty.
+func (v sortValue) foldedText(options sortOptions) string {
+	var text string
+
+	switch val := v.value.(type) {
+	case nil:
+		return ""
+
+	case string:
+		text = val
+
+	default:
+		text = fmt.Sprintf("%v", val)
+	}
+
+	if options.caseInsensitive {
+		text = strings.ToLower(text)
+	}
+
+	return text
+}
+
+// compareSortValues returns a negative number if a should come first, a
+// positive number if b should come first, or 0 if they are equal.  Values of
+// different kinds are compared as text.
+func compareSortValues(a, b sortValue, options sortOptions) int {
+	if a.kind == b.kind {
+		switch a.kind {
+		case sortValueNumber:
+			return compareNumbers(a, b)
+
+		case sortValueTime:
+			return compareTimes(a.time, b.time)
+
+		case sortValueText:
+			return compareText(a.text, b.text, options)
+
+		case sortValueMissing:
+			return 0
+		}
+	}
+
+	return compareText(a.foldedText(options), b.foldedText(options), options)
+}
+
+func compareNumbers(a, b sortValue) int {
+	if a.bigNumber == nil && b.bigNumber == nil {
+		switch {
+		case a.number < b.number:
+			return -1
+
+		case a.number > b.number:
+			return 1
+
+		default:
+			return 0
+		}
+	}
+
+	// NaN can't be converted, so there's no meaningful order
+	if (a.bigNumber == nil && math.IsNaN(a.number)) || (b.bigNumber == nil && math.IsNaN(b.number)) {
+		return 0
+	}
+
+	return a.asBigFloat().Cmp(b.asBigFloat())
+}
+
+func (v sortValue) asBigFloat() *big.Float {
+	if v.bigNumber != nil {
+		return v.bigNumber
+	}
+
+	return new(big.Float).SetFloat64(v.number)
+}
+
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+
+	case a.After(b):
+		return 1
+
+	default:
+		return 0
+	}
+}
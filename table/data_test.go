@@ -1,6 +1,9 @@
 package table
 
 import (
+	"database/sql"
+	"encoding/json"
+	"math/big"
 	"testing"
 	"time"
 
@@ -41,3 +44,65 @@ func TestAsNumber(t *testing.T) {
 	check(float32(3.3), true, 3.3)
 	check(StyledCell{Data: 3.3}, true, 3.3)
 }
+
+func TestToSortValueMissing(t *testing.T) {
+	var (
+		nilInt    *int
+		nilBigInt *big.Int
+	)
+
+	assert.Equal(t, sortValueMissing, toSortValue(nil, sortOptions{}).kind)
+	assert.Equal(t, sortValueMissing, toSortValue(nilInt, sortOptions{}).kind)
+	assert.Equal(t, sortValueMissing, toSortValue(nilBigInt, sortOptions{}).kind)
+	assert.Equal(t, sortValueMissing, toSortValue(sql.NullString{}, sortOptions{}).kind)
+	assert.Equal(t, sortValueMissing, toSortValue(sql.NullInt64{}, sortOptions{}).kind)
+	assert.Equal(t, sortValueMissing, toSortValue(StyledCell{Data: nilInt}, sortOptions{}).kind)
+}
+
+func TestToSortValueKeepsOriginalData(t *testing.T) {
+	number := 3
+	nullInt := sql.NullInt64{Int64: 3, Valid: true}
+
+	assert.Equal(t, &number, toSortValue(&number, sortOptions{}).data)
+	assert.Equal(t, nullInt, toSortValue(nullInt, sortOptions{}).data)
+	assert.Equal(t, "a", toSortValue(StyledCell{Data: "a"}, sortOptions{}).data)
+}
+
+func TestCompareSortValues(t *testing.T) {
+	one := 1
+	two := 2.0
+	now := time.Now()
+	huge, _ := new(big.Int).SetString("100000000000000000000000000000001", 10)
+	hugeSmaller, _ := new(big.Int).SetString("100000000000000000000000000000000", 10)
+
+	tests := []struct {
+		name     string
+		a        interface{}
+		b        interface{}
+		expected int
+	}{
+		{"Times", now, now.Add(time.Second), -1},
+		{"Equal times", now, now, 0},
+		{"Pointers", &one, &two, -1},
+		{"Pointer and number", &one, 2, -1},
+		{"json.Number", json.Number("10"), json.Number("9"), 1},
+		{"json.Number and int", json.Number("10"), 9, 1},
+		{"Big ints beyond float64 precision", huge, hugeSmaller, 1},
+		{"Big int and int", big.NewInt(10), 9, 1},
+		{"Big floats", big.NewFloat(1.5), big.NewFloat(2.5), -1},
+		{"sql.NullInt64", sql.NullInt64{Int64: 10, Valid: true}, sql.NullInt64{Int64: 9, Valid: true}, 1},
+		{"sql.NullFloat64 and int", sql.NullFloat64{Float64: 1.5, Valid: true}, 2, -1},
+		{"sql.NullTime", sql.NullTime{Time: now, Valid: true}, sql.NullTime{Time: now.Add(-time.Second), Valid: true}, 1},
+		{"sql.NullString", sql.NullString{String: "b", Valid: true}, sql.NullString{String: "a", Valid: true}, 1},
+		{"Mixed kinds as text", "10", 9, -1},
+		{"Missing as empty", nil, "a", -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			compared := compareSortValues(toSortValue(test.a, sortOptions{}), toSortValue(test.b, sortOptions{}), sortOptions{})
+
+			assert.Equal(t, test.expected, compared)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package table
+
+// DataSource provides rows to the table on demand, as an alternative to giving
+// the table every row up front with WithRows.  This is useful for very large
+// sets of data, since the table only fetches the rows that it's currently
+// showing.  Row indices are 0 based and are always within RowCount.
+//
+// If the table is filtered or sorted, the data source should also implement
+// QueryableDataSource to avoid the table fetching every row to filter and sort
+// them itself.
+type DataSource interface {
+	// RowCount returns the total number of rows.
+	RowCount() int
+
+	// Rows returns the rows from start up to but not including end.
+	Rows(start, end int) []Row
+}
+
+// DataSourceQuery describes how the table wants rows to be filtered and
+// sorted.
+type DataSourceQuery struct {
+	// Filter is the filter text entered by the user, or empty if no filter is
+	// active.
+	Filter string
+
+	// FilterColumnKeys are the keys of all columns that are filterable.
+	FilterColumnKeys []string
+
+	// ColumnFilters are the filters entered in the column filter row, keyed by
+	// column key, which only apply to their own column.
+	ColumnFilters map[string]string
+
+	// FacetFilters are the values checked in the facet picker, keyed by column
+	// key.  Rows must have one of the checked values for every column.
+	FacetFilters map[string][]string
+
+	// FilterMode is how the filter text should be matched.  With
+	// FilterModeRegex, Filter is always the last pattern that compiled.
+	FilterMode FilterMode
+
+	// SortColumns is the sort order in the same form as GetColumnSorting, so
+	// the last element is the primary sort.
+	SortColumns []SortColumn
+
+	// NaturalSort, CaseInsensitiveSort, and MissingSort are how text and
+	// missing data should be sorted, as set by WithNaturalSort,
+	// WithCaseInsensitiveSort, and WithMissingSort.
+	NaturalSort         bool
+	CaseInsensitiveSort bool
+	MissingSort         MissingSort
+}
+
+// QueryableDataSource is a DataSource that can filter and sort its own rows,
+// such as by pushing the query down to a database.
+type QueryableDataSource interface {
+	DataSource
+
+	// Query returns the rows that match the query in the requested order.  If
+	// nil is returned, the table falls back to fetching every row and filtering
+	// and sorting them itself.
+	Query(query DataSourceQuery) DataSource
+}
+
+// WithDataSource sets the table to fetch rows from the given data source
+// rather than from rows given with WithRows.  Only the rows currently in view
+// are fetched when rendering.  Row selection, cell editing, and keyed row
+// updates are not available with a data source, and the highlighted row does
+// not follow row IDs.  If the data changes in a way that the data source can't
+// reflect by itself, call WithDataSource again to refresh the table.
+func (m Model) WithDataSource(source DataSource) Model {
+	m.dataSource = source
+	m.dataSourceView = nil
+	m.rows = nil
+	m.invalidateVisibleRows()
+	m.refreshFacetPickerValues()
+
+	return m.WithHighlightedRow(m.rowCursorIndex)
+}
+
+// lazyVisibleRows returns the data source that visible rows can be read from
+// directly, or nil if there is no data source or the table needs to fetch every
+// row to filter and sort them itself.
+func (m *Model) lazyVisibleRows() DataSource {
+	if m.dataSource == nil {
+		return nil
+	}
+
+	if m.visibleRowCacheUpdated {
+		return m.dataSourceView
+	}
+
+	m.dataSourceView = m.queryDataSource()
+
+	// If there's no view, let GetVisibleRows fill the cache the usual way
+	if m.dataSourceView != nil {
+		m.visibleRowCache = nil
+		m.visibleRowSourceIndexCache = nil
+		m.visibleRowCacheUpdated = true
+	}
+
+	return m.dataSourceView
+}
+
+func (m *Model) queryDataSource() DataSource {
+	filterActive := m.filtered && m.GetIsFilterActive()
+
+	if !filterActive && len(m.sortOrder) == 0 {
+		return m.dataSource
+	}
+
+	queryable, ok := m.dataSource.(QueryableDataSource)
+
+	// A custom filter function can only be run against the rows themselves
+	if !ok || (filterActive && m.filterFunc != nil) {
+		return nil
+	}
+
+	query := DataSourceQuery{
+		SortColumns:         m.GetColumnSorting(),
+		NaturalSort:         m.sortOptions.natural,
+		CaseInsensitiveSort: m.sortOptions.caseInsensitive,
+		MissingSort:         m.sortOptions.missing,
+	}
+
+	if filterActive {
+		query.Filter = m.GetCurrentFilter()
+		query.FilterMode = m.filterMode
+
+		if m.hasColumnFilters() {
+			query.ColumnFilters = m.GetColumnFilterValues()
+		}
+
+		if m.hasFacetFilters() {
+			query.FacetFilters = m.GetFacetFilters()
+		}
+
+		// Only pass along a pattern that compiles
+		if m.filterMode == FilterModeRegex {
+			query.Filter = ""
+
+			if m.filterRegex != nil {
+				query.Filter = m.filterRegex.String()
+			}
+		}
+
+		for _, column := range m.columns {
+			if column.filterable {
+				query.FilterColumnKeys = append(query.FilterColumnKeys, column.key)
+			}
+		}
+	}
+
+	return queryable.Query(query)
+}
+
+// sourceRows returns every row before filtering and sorting.  With a data
+// source this fetches every row, so prefer the visible row helpers below.
+func (m *Model) sourceRows() []Row {
+	if m.dataSource == nil {
+		return m.rows
+	}
+
+	return m.dataSource.Rows(0, m.dataSource.RowCount())
+}
+
+func (m *Model) visibleRowCount() int {
+	if view := m.lazyVisibleRows(); view != nil {
+		return view.RowCount()
+	}
+
+	return len(m.GetVisibleRows())
+}
+
+func (m *Model) visibleRowAt(index int) Row {
+	if view := m.lazyVisibleRows(); view != nil {
+		return view.Rows(index, index+1)[0]
+	}
+
+	return m.GetVisibleRows()[index]
+}
+
+// visibleRowsBetween returns the visible rows between the given indices,
+// inclusive.
+func (m *Model) visibleRowsBetween(startIndex, endIndex int) []Row {
+	if endIndex < startIndex {
+		return nil
+	}
+
+	if view := m.lazyVisibleRows(); view != nil {
+		return view.Rows(startIndex, endIndex+1)
+	}
+
+	return m.GetVisibleRows()[startIndex : endIndex+1]
+}
@@ -0,0 +1,200 @@
+package table
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+type testDataSource struct {
+	count int
+
+	// Tracks how many rows have been fetched in total
+	fetched *int
+}
+
+func newTestDataSource(count int) testDataSource {
+	return testDataSource{
+		count:   count,
+		fetched: new(int),
+	}
+}
+
+func (s testDataSource) RowCount() int {
+	return s.count
+}
+
+func (s testDataSource) Rows(start, end int) []Row {
+	rows := make([]Row, 0, end-start)
+
+	for i := start; i < end; i++ {
+		rows = append(rows, NewRow(RowData{"id": i}))
+	}
+
+	*s.fetched += len(rows)
+
+	return rows
+}
+
+// Only supports filtering to a single exact ID, and reversing the order
+type testQueryableDataSource struct {
+	testDataSource
+
+	lastQuery *DataSourceQuery
+}
+
+type testQueryResult struct {
+	rows []Row
+}
+
+func (r testQueryResult) RowCount() int {
+	return len(r.rows)
+}
+
+func (r testQueryResult) Rows(start, end int) []Row {
+	return r.rows[start:end]
+}
+
+func (s testQueryableDataSource) Query(query DataSourceQuery) DataSource {
+	*s.lastQuery = query
+
+	result := testQueryResult{}
+
+	for i := 0; i < s.count; i++ {
+		if query.Filter != "" && fmt.Sprintf("%d", i) != query.Filter {
+			continue
+		}
+
+		result.rows = append(result.rows, NewRow(RowData{"id": i}))
+	}
+
+	if len(query.SortColumns) > 0 && query.SortColumns[0].Direction == SortDirectionDesc {
+		for i, j := 0, len(result.rows)-1; i < j; i, j = i+1, j-1 {
+			result.rows[i], result.rows[j] = result.rows[j], result.rows[i]
+		}
+	}
+
+	return result
+}
+
+func dataSourceTestModel(source DataSource) Model {
+	return New([]Column{
+		NewColumn("id", "ID", 7).WithFiltered(true),
+	}).WithDataSource(source).Focused(true)
+}
+
+func TestDataSourceOnlyFetchesVisibleRows(t *testing.T) {
+	source := newTestDataSource(1000000)
+	model := dataSourceTestModel(source).WithPageSize(3)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+
+	*source.fetched = 0
+
+	view := model.View()
+
+	assert.Contains(t, view, "┃      3┃\n┃      4┃\n┃      5┃")
+	assert.LessOrEqual(t, *source.fetched, 10, "Should only fetch the rows in view")
+	assert.Equal(t, 1000000, model.TotalRows())
+	assert.Equal(t, 3, model.HighlightedRow().Data["id"])
+}
+
+func TestDataSourceViewportScrolling(t *testing.T) {
+	source := newTestDataSource(1000000)
+	model := dataSourceTestModel(source).WithMaxHeight(7)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnd})
+
+	*source.fetched = 0
+
+	const expectedTable = `┏━━━━━━━┓
+┃     ID┃
+┣━━━━━━━┫
+┃ 999997┃
+┃ 999998┃
+┃ 999999┃
+┗━━━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+	assert.LessOrEqual(t, *source.fetched, 10, "Should only fetch the rows in view")
+}
+
+func TestDataSourceFallsBackToFilteringAndSorting(t *testing.T) {
+	model := dataSourceTestModel(newTestDataSource(20)).
+		Filtered(true).
+		WithFilterInputValue("1").
+		SortByDesc("id")
+
+	ids := []int{}
+
+	for _, row := range model.GetVisibleRows() {
+		ids = append(ids, row.Data["id"].(int))
+	}
+
+	assert.Equal(t, []int{19, 18, 17, 16, 15, 14, 13, 12, 11, 10, 1}, ids)
+}
+
+func TestDataSourceQueryPushdown(t *testing.T) {
+	source := testQueryableDataSource{
+		testDataSource: newTestDataSource(1000000),
+		lastQuery:      &DataSourceQuery{},
+	}
+
+	model := dataSourceTestModel(source).
+		Filtered(true).
+		WithFilterInputValue("12345").
+		SortByDesc("id")
+
+	assert.Equal(t, 1, model.TotalRows())
+	assert.Equal(t, 12345, model.HighlightedRow().Data["id"])
+	assert.Equal(t, DataSourceQuery{
+		Filter:           "12345",
+		FilterColumnKeys: []string{"id"},
+		SortColumns:      []SortColumn{{ColumnKey: "id", Direction: SortDirectionDesc}},
+	}, *source.lastQuery)
+	assert.Equal(t, 0, *source.fetched, "Should not fetch every row to filter")
+}
+
+func TestDataSourceReplacedByWithRows(t *testing.T) {
+	model := dataSourceTestModel(newTestDataSource(100))
+
+	model = model.WithRows([]Row{NewRow(RowData{"id": "only"})})
+
+	assert.Equal(t, 1, model.TotalRows())
+	assert.Equal(t, "only", model.HighlightedRow().Data["id"])
+}
+
+func TestDataSourceIgnoresSelection(t *testing.T) {
+	model := dataSourceTestModel(newTestDataSource(10)).SelectableRows(true)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+	assert.Empty(t, model.SelectedRows())
+	assert.Equal(t, 10, model.TotalRows())
+}
+
+func TestDataSourceFilterFuncSkipsQueryPushdown(t *testing.T) {
+	source := testQueryableDataSource{
+		testDataSource: newTestDataSource(20),
+		lastQuery:      &DataSourceQuery{},
+	}
+
+	evenOnly := func(columns []Column, row Row, filter string) bool {
+		return row.Data["id"].(int)%2 == 0
+	}
+
+	model := dataSourceTestModel(source).
+		Filtered(true).
+		WithFilterFunc(evenOnly).
+		WithFilterInputValue("even")
+
+	assert.Equal(t, 10, model.TotalRows())
+	assert.Equal(t, DataSourceQuery{}, *source.lastQuery, "Should not query the data source")
+}
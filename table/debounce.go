@@ -0,0 +1,101 @@
+package table
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// filterDebounceMsg applies the filter input to the rows once the user has
+// stopped typing for long enough.
+type filterDebounceMsg struct {
+	tableID int
+	tag     int
+}
+
+// WithFilterDebounce waits until the user has stopped typing in the filter
+// input for the given duration before filtering the rows, which avoids
+// filtering large tables again for every keystroke.  The filter input itself
+// still updates immediately.  Update returns a command that waits, and the
+// filter is applied once the command's message is passed back to Update.
+// UserEventFilterChanged is only generated when the filter is applied.  A
+// duration of 0 applies the filter immediately, which is the default.
+func (m Model) WithFilterDebounce(debounce time.Duration) Model {
+	m.filterDebounce = debounce
+
+	if debounce <= 0 && m.filterDebouncePending {
+		m.applyFilterText(m.appliedFilterText)
+	}
+
+	return m
+}
+
+// filterText returns the filter text that is applied to the rows, which may
+// be behind the filter input while waiting for the user to stop typing.
+func (m *Model) filterText() string {
+	if m.filterDebouncePending {
+		return m.appliedFilterText
+	}
+
+	return m.filterTextInput.Value()
+}
+
+// debounceFilter waits to apply the filter input until the user has stopped
+// typing, keeping the given filter text applied in the meantime.
+func (m *Model) debounceFilter(appliedText string) tea.Cmd {
+	if !m.filterDebouncePending {
+		m.appliedFilterText = appliedText
+		m.filterDebouncePending = true
+	}
+
+	// Any earlier ticks that are still waiting are ignored when they arrive
+	m.filterDebounceTag++
+
+	tableID := m.tableID
+	tag := m.filterDebounceTag
+
+	return tea.Tick(m.filterDebounce, func(time.Time) tea.Msg {
+		return filterDebounceMsg{
+			tableID: tableID,
+			tag:     tag,
+		}
+	})
+}
+
+// cancelFilterDebounce stops waiting to apply the filter input, such as when
+// the filter is set directly.
+func (m *Model) cancelFilterDebounce() {
+	m.filterDebouncePending = false
+	m.appliedFilterText = ""
+}
+
+// applyFilterText filters the rows by what's currently in the filter input,
+// given the filter text that was applied before.
+func (m *Model) applyFilterText(previous string) {
+	m.cancelFilterDebounce()
+	m.updateFilterRegex()
+	m.pageFirst()
+	m.invalidateFilteredRows()
+
+	if m.filterTextInput.Value() != previous {
+		m.appendUserEvent(UserEventFilterChanged{
+			Filter: m.filterTextInput.Value(),
+		})
+	}
+}
+
+func (m Model) receiveFilterDebounce(msg filterDebounceMsg) Model {
+	if msg.tableID != m.tableID || msg.tag != m.filterDebounceTag || !m.filterDebouncePending {
+		return m
+	}
+
+	m.applyFilterText(m.appliedFilterText)
+
+	if m.following {
+		m.pinToNewestRow()
+	}
+
+	m.updateVerticalScroll()
+
+	return m
+}
@@ -0,0 +1,111 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func debounceTestModel() Model {
+	model := asyncTestModel().
+		WithAsyncFilterSort(false).
+		WithFilterDebounce(time.Millisecond)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+
+	return model
+}
+
+func TestFilterDebounceWaitsForTypingToPause(t *testing.T) {
+	model := debounceTestModel()
+
+	model, firstCmd := model.Update(runesMsg("item"))
+	model, secondCmd := model.Update(runesMsg(" 9"))
+
+	assert.Equal(t, "item 9", model.filterTextInput.Value(), "Should update the input right away")
+	assert.Len(t, model.GetVisibleRows(), 10, "Should not filter yet")
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+
+	model, _ = model.Update(firstCmd())
+
+	assert.Len(t, model.GetVisibleRows(), 10, "Should ignore the tick from before the last keystroke")
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+
+	model, cmd := model.Update(secondCmd())
+
+	assert.Nil(t, cmd)
+	assert.Equal(t, []string{"item 9"}, visibleNames(model))
+	assert.Equal(t, []UserEvent{UserEventFilterChanged{Filter: "item 9"}}, model.GetLastUpdateUserEvents())
+
+	model, _ = model.Update(secondCmd())
+
+	assert.Empty(t, model.GetLastUpdateUserEvents(), "Should only apply the filter once")
+}
+
+func TestFilterDebounceIgnoresOtherTables(t *testing.T) {
+	model := debounceTestModel()
+	other := debounceTestModel()
+
+	other, _ = other.Update(runesMsg("w"))
+	model, cmd := model.Update(runesMsg("9"))
+	msg := cmd()
+
+	other, _ = other.Update(msg)
+
+	assert.Len(t, other.GetVisibleRows(), 10)
+
+	model, _ = model.Update(msg)
+
+	assert.Equal(t, []string{"item 9"}, visibleNames(model))
+}
+
+func TestFilterDebounceCancelledBySettingFilter(t *testing.T) {
+	model := debounceTestModel()
+
+	model, cmd := model.Update(runesMsg("9"))
+	model = model.WithFilterInputValue("fire")
+
+	assert.Len(t, model.GetVisibleRows(), 5)
+
+	model, _ = model.Update(cmd())
+
+	assert.Len(t, model.GetVisibleRows(), 5)
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+}
+
+func TestFilterDebounceClearWhilePending(t *testing.T) {
+	model := debounceTestModel()
+
+	model, cmd := model.Update(runesMsg("9"))
+	model, _ = model.Update(cmd())
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	assert.Len(t, model.GetVisibleRows(), 10)
+	assert.Equal(t, []UserEvent{UserEventFilterChanged{}}, model.GetLastUpdateUserEvents())
+}
+
+func TestFilterDebounceDisabledAppliesPendingFilter(t *testing.T) {
+	model := debounceTestModel()
+
+	model, _ = model.Update(runesMsg("9"))
+	model = model.WithFilterDebounce(0)
+
+	assert.Equal(t, []string{"item 9"}, visibleNames(model))
+}
+
+func TestFilterWithoutDebounceChangesOnEveryKeystroke(t *testing.T) {
+	model := debounceTestModel().WithFilterDebounce(0)
+
+	model, cmd := model.Update(runesMsg("9"))
+
+	assert.Nil(t, cmd)
+	assert.Equal(t, []string{"item 9"}, visibleNames(model))
+	assert.Equal(t, []UserEvent{UserEventFilterChanged{Filter: "9"}}, model.GetLastUpdateUserEvents())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+
+	assert.Empty(t, model.GetLastUpdateUserEvents(), "Moving the cursor doesn't change the filter")
+}
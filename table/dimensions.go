@@ -5,8 +5,15 @@ import (
 )
 
 func (m *Model) recalculateWidth() {
-	if m.targetTotalWidth != 0 {
-		m.totalWidth = m.targetTotalWidth
+	targetWidth := m.targetTotalWidth
+
+	// The scrollbar is drawn to the right of the table, so leave room for it
+	if targetWidth != 0 {
+		targetWidth = max(targetWidth-m.scrollbarWidth(), 1)
+	}
+
+	if targetWidth != 0 {
+		m.totalWidth = targetWidth
 	} else {
 		total := 0
 
@@ -17,11 +24,22 @@ func (m *Model) recalculateWidth() {
 		m.totalWidth = total + len(m.columns) + 1
 	}
 
-	updateColumnWidths(m.columns, m.targetTotalWidth)
+	updateColumnWidths(m.columns, targetWidth)
 
 	m.recalculateLastHorizontalColumn()
 }
 
+// maxTableWidth returns the most the table itself can take up horizontally
+// before it overflows, which leaves room for the scrollbar.  Returns 0 if there
+// is no maximum.
+func (m *Model) maxTableWidth() int {
+	if m.maxTotalWidth == 0 {
+		return 0
+	}
+
+	return max(m.maxTotalWidth-m.scrollbarWidth(), 1)
+}
+
 // Updates column width in-place.  This could be optimized but should be called
 // very rarely so we prioritize simplicity over performance here.
 func updateColumnWidths(cols []Column, totalWidth int) {
@@ -92,6 +110,10 @@ func (m *Model) recalculateHeight() {
 	m.metaHeight = headerHeight + footerHeight
 }
 
+func (m *Model) hasHeightConstraint() bool {
+	return m.minimumHeight > 0 || m.maxHeight > 0
+}
+
 func (m *Model) calculatePadding(numRows int) int {
 	if m.minimumHeight == 0 {
 		return 0
@@ -0,0 +1,143 @@
+package table
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CellEditValidator checks the text that the user entered when editing a cell.
+// It returns the value to store in the row data, which allows the text to be
+// parsed into a more specific type such as an int.  If an error is returned,
+// the value is not committed and the error is shown in the footer so that the
+// user can fix their input.
+type CellEditValidator func(input string) (interface{}, error)
+
+// startCellEdit opens the editor for the highlighted cell, if its column is
+// editable.  The highlighted cell is only shown with the cell cursor, so
+// nothing is edited without it.
+func (m *Model) startCellEdit() {
+	if !m.cellCursor ||
+		m.dataSource != nil ||
+		m.visibleRowCount() == 0 ||
+		m.columnCursorIndex < 0 ||
+		m.columnCursorIndex >= len(m.columns) {
+		return
+	}
+
+	column := m.columns[m.columnCursorIndex]
+
+	if !column.editable {
+		return
+	}
+
+	value := ""
+
+	if data, exists := m.HighlightedRow().Data[column.key]; exists {
+		if styled, isStyled := data.(StyledCell); isStyled {
+			data = styled.Data
+		}
+
+		value = fmt.Sprintf("%v", data)
+	}
+
+	m.cellEditTextInput.Prompt = ""
+	m.cellEditTextInput.Width = max(column.width-1, 1)
+	m.cellEditTextInput.SetValue(value)
+	m.cellEditTextInput.CursorEnd()
+	m.cellEditTextInput.Focus()
+
+	m.setCellEditError(nil)
+}
+
+func (m *Model) cancelCellEdit() {
+	m.cellEditTextInput.Blur()
+	m.setCellEditError(nil)
+}
+
+func (m *Model) commitCellEdit() {
+	column := m.columns[m.columnCursorIndex]
+	input := m.cellEditTextInput.Value()
+
+	var newValue interface{} = input
+
+	if column.editValidator != nil {
+		validated, err := column.editValidator(input)
+
+		if err != nil {
+			m.setCellEditError(err)
+
+			return
+		}
+
+		newValue = validated
+	}
+
+	row := m.HighlightedRow()
+	oldValue := row.Data[column.key]
+
+	// Keep any existing style if the user is only changing the data
+	if styled, isStyled := oldValue.(StyledCell); isStyled {
+		newValue = NewStyledCell(newValue, styled.Style)
+	}
+
+	// Copy everything to avoid editing data that the caller may still hold
+	newData := make(RowData, len(row.Data)+1)
+
+	for key, val := range row.Data {
+		newData[key] = val
+	}
+
+	newData[column.key] = newValue
+
+	rows := make([]Row, len(m.rows))
+	copy(rows, m.rows)
+	rows[m.visibleRowSourceIndex(m.rowCursorIndex)].Data = newData
+
+	m.rows = rows
+	m.invalidateVisibleRows()
+	m.refreshFacetPickerValues()
+
+	m.cellEditTextInput.Blur()
+	m.setCellEditError(nil)
+
+	m.appendUserEvent(UserEventCellEdited{
+		RowIndex:  m.rowCursorIndex,
+		ColumnKey: column.key,
+		Old:       oldValue,
+		New:       newValue,
+	})
+}
+
+func (m *Model) setCellEditError(err error) {
+	hadFooter := m.hasFooter()
+
+	m.cellEditError = err
+
+	if m.hasHeightConstraint() && hadFooter != m.hasFooter() {
+		m.recalculateHeight()
+	}
+}
+
+func (m Model) updateCellEditTextInput(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keyMap.CellEditCommit):
+			m.commitCellEdit()
+
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.CellEditCancel):
+			m.cancelCellEdit()
+
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+
+	m.cellEditTextInput, cmd = m.cellEditTextInput.Update(msg)
+
+	return m, cmd
+}
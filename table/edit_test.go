@@ -0,0 +1,205 @@
+package table
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+var errNotANumber = errors.New("not a number")
+
+func validateInt(input string) (interface{}, error) {
+	val, err := strconv.Atoi(input)
+
+	if err != nil {
+		return nil, errNotANumber
+	}
+
+	return val, nil
+}
+
+func editTestModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 8).WithEditable(nil),
+		NewColumn("count", "Count", 6).WithEditable(validateInt),
+		NewColumn("id", "ID", 4),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "first", "count": 1, "id": "a"}),
+		NewRow(RowData{"name": "second", "count": 2, "id": "b"}),
+	}).Focused(true).WithCellCursor(true)
+}
+
+func editTestTypeText(model Model, text string) Model {
+	for _, r := range text {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	return model
+}
+
+func editTestClear(model Model) Model {
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+
+	return model
+}
+
+func TestCellEditCommitsValue(t *testing.T) {
+	model := editTestModel()
+	originalRows := model.rows
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	assert.True(t, model.GetIsEditingCell())
+
+	model = editTestTypeText(model, "!")
+
+	// Keys should go to the editor, not move the table
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, model.GetIsEditingCell())
+	assert.Equal(t, "first!", model.HighlightedCell())
+	assert.Equal(t, []UserEvent{
+		UserEventCellEdited{
+			RowIndex:  0,
+			ColumnKey: "name",
+			Old:       "first",
+			New:       "first!",
+		},
+	}, model.GetLastUpdateUserEvents())
+
+	assert.Equal(t, "first", originalRows[0].Data["name"], "Should not modify the original row data")
+}
+
+func TestCellEditCancel(t *testing.T) {
+	model := editTestModel()
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	model = editTestTypeText(model, "abc")
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEscape})
+
+	assert.False(t, model.GetIsEditingCell())
+	assert.Equal(t, "first", model.HighlightedCell())
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+}
+
+func TestCellEditNotEditableColumn(t *testing.T) {
+	model := editTestModel().WithHighlightedColumn("id")
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+
+	assert.False(t, model.GetIsEditingCell())
+}
+
+func TestCellEditWithoutCellCursor(t *testing.T) {
+	model := editTestModel().WithCellCursor(false)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+
+	assert.False(t, model.GetIsEditingCell())
+
+	model = editTestTypeText(model, "z")
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.Equal(t, "first", model.GetVisibleRows()[0].Data["name"])
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+}
+
+func TestCellEditValidation(t *testing.T) {
+	model := editTestModel().
+		WithHighlightedColumn("count").
+		WithHighlightedRow(1)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	model = editTestClear(model)
+	model = editTestTypeText(model, "x")
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.True(t, model.GetIsEditingCell(), "Should still be editing after failed validation")
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+	assert.Contains(t, model.View(), errNotANumber.Error())
+
+	model = editTestClear(model)
+	model = editTestTypeText(model, "17")
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.False(t, model.GetIsEditingCell())
+	assert.Equal(t, 17, model.HighlightedCell(), "Validator should convert the type")
+	assert.NotContains(t, model.View(), errNotANumber.Error())
+	assert.Equal(t, []UserEvent{
+		UserEventCellEdited{
+			RowIndex:  1,
+			ColumnKey: "count",
+			Old:       2,
+			New:       17,
+		},
+	}, model.GetLastUpdateUserEvents())
+}
+
+func TestCellEditWithFilteredAndSortedRows(t *testing.T) {
+	model := editTestModel().
+		WithColumns([]Column{
+			NewColumn("name", "Name", 8).WithEditable(nil).WithFiltered(true),
+		}).
+		WithRows([]Row{
+			NewRow(RowData{"name": "c"}),
+			NewRow(RowData{"name": "a"}),
+			NewRow(RowData{"name": "b"}),
+			NewRow(RowData{"name": "x"}),
+		}).
+		Filtered(true).
+		WithFilterInputValue("x").
+		SortByAsc("name")
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	model = editTestTypeText(model, "y")
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	model = model.WithFilterInputValue("")
+
+	names := []string{}
+
+	for _, row := range model.GetVisibleRows() {
+		names = append(names, row.Data["name"].(string))
+	}
+
+	assert.Equal(t, []string{"a", "b", "c", "xy"}, names)
+}
+
+func TestCellEditKeepsStyle(t *testing.T) {
+	style := lipgloss.NewStyle().Bold(true)
+
+	model := editTestModel().WithRows([]Row{
+		NewRow(RowData{"name": NewStyledCell("styled", style)}),
+	})
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	assert.Equal(t, "styled", model.cellEditTextInput.Value(), "Should edit the underlying data")
+
+	model = editTestTypeText(model, "!")
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.Equal(t, NewStyledCell("styled!", style), model.HighlightedCell())
+}
+
+func TestCellEditRendersInCell(t *testing.T) {
+	model := editTestModel().
+		HighlightStyle(lipgloss.NewStyle()).
+		HighlightCellStyle(lipgloss.NewStyle())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	model = editTestClear(model)
+	model = editTestTypeText(model, "new")
+
+	// The cursor is rendered with a reverse style after the text
+	lines := strings.Split(model.View(), "\n")
+
+	assert.True(t, strings.HasPrefix(lines[3], "┃new"), "Editor should be left aligned in the cell: %q", lines[3])
+	assert.True(t, strings.HasSuffix(lines[3], "    ┃     1┃   a┃"), "Editor should fit in the cell: %q", lines[3])
+	assert.Equal(t, "┃  second┃     2┃   b┃", lines[4])
+}
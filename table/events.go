@@ -41,6 +41,17 @@ type UserEventHighlightedIndexChanged struct {
 	SelectedRowIndex int
 }
 
+// UserEventHighlightedCellChanged indicates that the user has moved the cell
+// cursor to a new cell, either by changing rows or columns.  Only generated
+// when the cell cursor is enabled with WithCellCursor.
+type UserEventHighlightedCellChanged struct {
+	PreviousRowIndex  int
+	PreviousColumnKey string
+
+	RowIndex  int
+	ColumnKey string
+}
+
 // UserEventRowSelectToggled indicates that the user has either selected or
 // deselected a row by toggling the selection.  The event contains information
 // about which row index was selected and whether it was selected or deselected.
@@ -49,12 +60,82 @@ type UserEventRowSelectToggled struct {
 	IsSelected bool
 }
 
+// UserEventRowsSelectionChanged indicates that the user has changed the
+// selection of multiple rows at once, such as by selecting all rows or a range
+// of rows.  A single event is generated for the whole change.  The indices are
+// the rows that changed, in the same form as GetHighlightedRowIndex.
+type UserEventRowsSelectionChanged struct {
+	SelectedRowIndices   []int
+	DeselectedRowIndices []int
+}
+
+// UserEventFollowModeChanged indicates that the table has started or stopped
+// following the newest row because of user input.  Only generated when follow
+// mode is enabled with WithFollowMode.
+type UserEventFollowModeChanged struct {
+	IsFollowing bool
+}
+
+// UserEventSortChanged indicates that the user has changed how the table is
+// sorted, either with the sort keys or by clicking a header.  SortColumns is
+// the new sort order in the same form as GetColumnSorting.
+type UserEventSortChanged struct {
+	SortColumns []SortColumn
+}
+
+// UserEventCellEdited indicates that the user has edited a cell and the new
+// value passed validation and was saved to the row.
+type UserEventCellEdited struct {
+	RowIndex  int
+	ColumnKey string
+
+	Old interface{}
+	New interface{}
+}
+
 // UserEventFilterInputFocused indicates that the user has focused the filter
 // text input, so that any other typing will type into the filter field.  Only
-// activates for the built-in filter text box.
+// activates for the built-in filter text box or the column filter row.
 type UserEventFilterInputFocused struct{}
 
 // UserEventFilterInputUnfocused indicates that the user has unfocused the filter
 // text input, which means the user is done typing into the filter field.  Only
-// activates for the built-in filter text box.
+// activates for the built-in filter text box or the column filter row.
 type UserEventFilterInputUnfocused struct{}
+
+// UserEventFilterChanged indicates that the filter text applied to the rows
+// has changed because the user typed into the filter input or cleared the
+// filter.  With WithFilterDebounce, this is only generated once the user stops
+// typing and the filter is applied, rather than for every keystroke.
+type UserEventFilterChanged struct {
+	Filter string
+}
+
+// UserEventFilterModeChanged indicates that the user has switched to another
+// filter mode with the FilterModeToggle key.
+type UserEventFilterModeChanged struct {
+	FilterMode FilterMode
+}
+
+// UserEventFacetFilterChanged indicates that the user has checked or unchecked
+// a value in the facet picker.  Values are all values that are now checked for
+// the column, which may be empty.
+type UserEventFacetFilterChanged struct {
+	ColumnKey string
+	Values    []string
+}
+
+// UserEventCellClicked indicates that the user has clicked on a cell with the
+// mouse.  The row at RowIndex will also be highlighted.  ColumnKey is empty if
+// the click landed on something that isn't a column, such as an overflow
+// indicator.
+type UserEventCellClicked struct {
+	RowIndex  int
+	ColumnKey string
+}
+
+// UserEventHeaderClicked indicates that the user has clicked on a column header
+// with the mouse, which toggles sorting by that column.
+type UserEventHeaderClicked struct {
+	ColumnKey string
+}
@@ -205,3 +205,40 @@ func TestFilterFocusEvents(t *testing.T) {
 		assert.FailNow(t, "Unexpected event type")
 	}
 }
+
+func TestUserEventHighlightedCellChanged(t *testing.T) {
+	model := New([]Column{
+		NewColumn("a", "A", 3),
+		NewColumn("b", "B", 3),
+	}).WithRows([]Row{
+		NewRow(RowData{}),
+		NewRow(RowData{}),
+	}).Focused(true).WithCellCursor(true)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlRight})
+	assert.Equal(t, []UserEvent{
+		UserEventHighlightedCellChanged{
+			PreviousRowIndex:  0,
+			PreviousColumnKey: "a",
+			RowIndex:          0,
+			ColumnKey:         "b",
+		},
+	}, model.GetLastUpdateUserEvents())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, []UserEvent{
+		UserEventHighlightedIndexChanged{
+			PreviousRowIndex: 0,
+			SelectedRowIndex: 1,
+		},
+		UserEventHighlightedCellChanged{
+			PreviousRowIndex:  0,
+			PreviousColumnKey: "b",
+			RowIndex:          1,
+			ColumnKey:         "b",
+		},
+	}, model.GetLastUpdateUserEvents())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlRight})
+	assert.Empty(t, model.GetLastUpdateUserEvents(), "Should not generate an event when nothing moved")
+}
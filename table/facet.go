@@ -0,0 +1,309 @@
+package table
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The most values shown in the facet picker at once, scrolling to follow the
+// cursor if there are more
+const facetPickerMaxLines = 10
+
+// facetValue is a distinct value in a column and how many rows have it.
+type facetValue struct {
+	value string
+	count int
+
+	// The first data seen with this value, used to sort numbers numerically
+	data interface{}
+}
+
+// facetKey returns the value used to group a cell's data in the facet picker
+// and to match it against a facet filter.
+func facetKey(data interface{}) string {
+	if styled, isStyled := data.(StyledCell); isStyled {
+		data = styled.Data
+	}
+
+	return filterTargetString(data)
+}
+
+// facetValues returns the distinct values of the given column across all rows,
+// ignoring any filters, with how many rows have each value.  Numbers are sorted
+// numerically and everything else alphabetically.
+func (m *Model) facetValues(columnKey string) []facetValue {
+	indices := map[string]int{}
+	values := []facetValue{}
+
+	for _, row := range m.sourceRows() {
+		data, exists := row.Data[columnKey]
+
+		if !exists {
+			continue
+		}
+
+		value := facetKey(data)
+
+		if index, seen := indices[value]; seen {
+			values[index].count++
+
+			continue
+		}
+
+		indices[value] = len(values)
+		values = append(values, facetValue{
+			value: value,
+			count: 1,
+			data:  data,
+		})
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		numberI, isNumberI := asNumber(values[i].data)
+		numberJ, isNumberJ := asNumber(values[j].data)
+
+		if isNumberI && isNumberJ {
+			return numberI < numberJ
+		}
+
+		return values[i].value < values[j].value
+	})
+
+	return values
+}
+
+// OpenFacetPicker opens a list of the distinct values in the given column with
+// how many rows have each value, replacing the footer while open.  The user
+// can check and uncheck values to only show rows with one of the checked
+// values.  The column must be filterable, and filtering must be enabled with
+// Filtered.
+func (m Model) OpenFacetPicker(columnKey string) Model {
+	m.openFacetPicker(columnKey)
+
+	return m
+}
+
+// CloseFacetPicker closes the facet picker, keeping any values that were
+// checked.
+func (m Model) CloseFacetPicker() Model {
+	m.closeFacetPicker()
+
+	return m
+}
+
+// WithFacetFilter only shows rows where the given column has one of the given
+// values, as if the user had checked them in the facet picker.  Values are
+// compared to each cell's data as a string.  An empty list of values removes
+// the facet filter for the column.
+func (m Model) WithFacetFilter(columnKey string, values []string) Model {
+	m.setFacetFilter(columnKey, values)
+	m.pageFirst()
+
+	return m
+}
+
+func (m *Model) openFacetPicker(columnKey string) {
+	if !m.filtered {
+		return
+	}
+
+	for _, column := range m.columns {
+		if column.key == columnKey && column.filterable {
+			m.facetPickerColumnKey = columnKey
+			m.facetPickerCursor = 0
+			m.facetPickerValues = m.facetValues(columnKey)
+
+			if m.hasHeightConstraint() {
+				m.recalculateHeight()
+			}
+
+			return
+		}
+	}
+}
+
+func (m *Model) closeFacetPicker() {
+	m.facetPickerColumnKey = ""
+	m.facetPickerValues = nil
+
+	if m.hasHeightConstraint() {
+		m.recalculateHeight()
+	}
+}
+
+func (m Model) isFacetPickerOpen() bool {
+	return m.facetPickerColumnKey != ""
+}
+
+// refreshFacetPickerValues reads the values listed in the facet picker again
+// after the rows have changed, if it's open.
+func (m *Model) refreshFacetPickerValues() {
+	if !m.isFacetPickerOpen() {
+		return
+	}
+
+	m.facetPickerValues = m.facetValues(m.facetPickerColumnKey)
+	m.facetPickerCursor = max(0, min(m.facetPickerCursor, len(m.facetPickerValues)-1))
+}
+
+// setFacetFilter copies the filters so that older models are unaffected.
+func (m *Model) setFacetFilter(columnKey string, values []string) {
+	filters := make(map[string][]string, len(m.facetFilters)+1)
+
+	for k, v := range m.facetFilters {
+		filters[k] = v
+	}
+
+	if len(values) == 0 {
+		delete(filters, columnKey)
+	} else {
+		filters[columnKey] = append([]string{}, values...)
+	}
+
+	m.facetFilters = filters
+	m.invalidateFilterAndSort()
+}
+
+func (m *Model) isFacetValueChecked(columnKey string, value string) bool {
+	for _, checked := range m.facetFilters[columnKey] {
+		if checked == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *Model) toggleFacetValue(columnKey string, value string) {
+	checked := []string{}
+	found := false
+
+	for _, existing := range m.facetFilters[columnKey] {
+		if existing == value {
+			found = true
+
+			continue
+		}
+
+		checked = append(checked, existing)
+	}
+
+	if !found {
+		checked = append(checked, value)
+	}
+
+	m.setFacetFilter(columnKey, checked)
+	m.pageFirst()
+
+	m.appendUserEvent(UserEventFacetFilterChanged{
+		ColumnKey: columnKey,
+		Values:    m.GetFacetFilters()[columnKey],
+	})
+}
+
+func (m Model) updateFacetPicker(msg tea.Msg) Model {
+	keyMsg, ok := msg.(tea.KeyMsg)
+
+	if !ok {
+		return m
+	}
+
+	values := m.facetPickerValues
+
+	switch {
+	case key.Matches(keyMsg, m.keyMap.FacetPickerClose):
+		m.closeFacetPicker()
+
+	case key.Matches(keyMsg, m.keyMap.RowUp):
+		if m.facetPickerCursor > 0 {
+			m.facetPickerCursor--
+		}
+
+	case key.Matches(keyMsg, m.keyMap.RowDown):
+		if m.facetPickerCursor < len(values)-1 {
+			m.facetPickerCursor++
+		}
+
+	case key.Matches(keyMsg, m.keyMap.FacetPickerToggle):
+		if m.facetPickerCursor < len(values) {
+			m.toggleFacetValue(m.facetPickerColumnKey, values[m.facetPickerCursor].value)
+		}
+	}
+
+	return m
+}
+
+// facetPickerLines returns the lines to show in place of the footer while the
+// facet picker is open, each no wider than the given width.
+func (m *Model) facetPickerLines(width int) []string {
+	title := m.facetPickerColumnKey
+
+	for _, column := range m.columns {
+		if column.key == m.facetPickerColumnKey {
+			title = column.title
+		}
+	}
+
+	lines := []string{limitStr(title, width)}
+
+	values := m.facetPickerValues
+
+	// Scroll to keep the cursor in view
+	start := max(0, m.facetPickerCursor-facetPickerMaxLines+1)
+	end := min(len(values), start+facetPickerMaxLines)
+
+	for i := start; i < end; i++ {
+		cursor := "  "
+
+		if i == m.facetPickerCursor {
+			cursor = "> "
+		}
+
+		checkbox := m.unselectedText
+
+		if m.isFacetValueChecked(m.facetPickerColumnKey, values[i].value) {
+			checkbox = m.selectedText
+		}
+
+		line := fmt.Sprintf("%s%s %s (%d)", cursor, checkbox, values[i].value, values[i].count)
+
+		lines = append(lines, limitStr(line, width))
+	}
+
+	return lines
+}
+
+func (m *Model) hasFacetFilters() bool {
+	return m.filtered && len(m.facetFilters) > 0
+}
+
+func (m Model) getFacetFilteredRows(rows []Row) []Row {
+	if !m.hasFacetFilters() {
+		return rows
+	}
+
+	filteredRows := make([]Row, 0)
+
+	for _, row := range rows {
+		if m.isRowMatchedByFacets(row) {
+			filteredRows = append(filteredRows, row)
+		}
+	}
+
+	return filteredRows
+}
+
+func (m Model) isRowMatchedByFacets(row Row) bool {
+	for columnKey := range m.facetFilters {
+		data, exists := row.Data[columnKey]
+
+		if !exists || !m.isFacetValueChecked(columnKey, facetKey(data)) {
+			return false
+		}
+	}
+
+	return true
+}
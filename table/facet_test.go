@@ -0,0 +1,215 @@
+package table
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func facetTestModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 10).WithFiltered(true),
+		NewColumn("type", "Type", 8).WithFiltered(true),
+		NewColumn("hp", "HP", 4).WithFiltered(true),
+		NewColumn("notes", "Notes", 6),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "Pikachu", "type": "Electric", "hp": 35}),
+		NewRow(RowData{"name": "Charmander", "type": NewStyledCell("Fire", defaultHighlightStyle), "hp": 39}),
+		NewRow(RowData{"name": "Vulpix", "type": "Fire", "hp": 100}),
+		NewRow(RowData{"name": "Missingno"}),
+	}).Filtered(true).Focused(true)
+}
+
+func TestFacetValues(t *testing.T) {
+	model := facetTestModel().WithFilterInputValue("pika")
+
+	// Counted from every row, ignoring filters and missing data
+	assert.Equal(t, []facetValue{
+		{value: "Electric", count: 1, data: "Electric"},
+		{value: "Fire", count: 2, data: NewStyledCell("Fire", defaultHighlightStyle)},
+	}, model.facetValues("type"))
+
+	// Numbers sort numerically
+	values := model.facetValues("hp")
+
+	assert.Equal(t, "35", values[0].value)
+	assert.Equal(t, "39", values[1].value)
+	assert.Equal(t, "100", values[2].value)
+}
+
+func TestFacetPickerRenders(t *testing.T) {
+	model := facetTestModel().OpenFacetPicker("type")
+
+	const expectedTable = `┏━━━━━━━━━━┳━━━━━━━━┳━━━━┳━━━━━━┓
+┃      Name┃    Type┃  HP┃ Notes┃
+┣━━━━━━━━━━╋━━━━━━━━╋━━━━╋━━━━━━┫
+┃   Pikachu┃Electric┃  35┃      ┃
+┃Charmander┃    Fire┃  39┃      ┃
+┃    Vulpix┃    Fire┃ 100┃      ┃
+┃ Missingno┃        ┃    ┃      ┃
+┣━━━━━━━━━━┻━━━━━━━━┻━━━━┻━━━━━━┫
+┃Type                           ┃
+┃> [ ] Electric (1)             ┃
+┃  [ ] Fire (2)                 ┃
+┗━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+	assert.True(t, model.GetIsFacetPickerOpen())
+
+	// Shown even if the footer is hidden
+	model = model.WithFooterVisibility(false)
+
+	assert.Contains(t, model.View(), "Electric (1)")
+}
+
+func TestFacetPickerChecksValues(t *testing.T) {
+	model := facetTestModel().WithCellCursor(true)
+
+	// Move the column cursor to the type column and open the picker
+	model = typeKeys(model, runesMsg(">"), runesMsg("f"))
+
+	assert.True(t, model.GetIsFacetPickerOpen())
+
+	model = typeKeys(model, tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeySpace})
+
+	assert.Equal(t, map[string][]string{"type": {"Fire"}}, model.GetFacetFilters())
+	assert.Equal(t, []UserEvent{UserEventFacetFilterChanged{ColumnKey: "type", Values: []string{"Fire"}}}, model.GetLastUpdateUserEvents())
+	assert.Equal(t, []string{"Charmander", "Vulpix"}, visibleNames(model))
+	assert.True(t, model.GetIsFilterActive())
+
+	model = typeKeys(model, tea.KeyMsg{Type: tea.KeyUp}, tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.Equal(t, map[string][]string{"type": {"Fire", "Electric"}}, model.GetFacetFilters())
+	assert.Equal(t, []string{"Pikachu", "Charmander", "Vulpix"}, visibleNames(model))
+
+	// Closing keeps the filter
+	model = typeKeys(model, tea.KeyMsg{Type: tea.KeyEsc})
+
+	assert.False(t, model.GetIsFacetPickerOpen())
+	assert.Len(t, model.GetVisibleRows(), 3)
+
+	// Unchecking everything removes the filter
+	model = typeKeys(model, runesMsg("f"), tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.Empty(t, model.GetFacetFilters())
+	assert.Equal(t, []UserEvent{UserEventFacetFilterChanged{ColumnKey: "type", Values: nil}}, model.GetLastUpdateUserEvents())
+	assert.Len(t, model.GetVisibleRows(), 4)
+	assert.False(t, model.GetIsFilterActive())
+}
+
+func TestFacetFilterCombinesWithTextFilter(t *testing.T) {
+	model := facetTestModel().
+		WithFacetFilter("type", []string{"Fire"}).
+		WithFacetFilter("hp", []string{"39", "100", "35"}).
+		WithFilterInputValue("vul")
+
+	assert.Equal(t, []string{"Vulpix"}, visibleNames(model))
+	assert.Equal(t, "vul", model.GetCurrentFilter())
+
+	model = model.WithFilterInputValue("")
+
+	assert.Equal(t, []string{"Charmander", "Vulpix"}, visibleNames(model))
+
+	// Clearing the filter clears the facets too
+	model = typeKeys(model, tea.KeyMsg{Type: tea.KeyEsc})
+
+	assert.Empty(t, model.GetFacetFilters())
+	assert.Len(t, model.GetVisibleRows(), 4)
+}
+
+func TestFacetPickerKeyNeedsCellCursor(t *testing.T) {
+	model := typeKeys(facetTestModel(), runesMsg("f"))
+
+	assert.False(t, model.GetIsFacetPickerOpen(), "The highlighted column isn't shown")
+
+	model = typeKeys(model.WithCellCursor(true), runesMsg("f"))
+
+	assert.True(t, model.GetIsFacetPickerOpen())
+}
+
+func TestFacetPickerOnlyForFilterableColumns(t *testing.T) {
+	model := facetTestModel().OpenFacetPicker("notes")
+
+	assert.False(t, model.GetIsFacetPickerOpen())
+
+	model = facetTestModel().Filtered(false).OpenFacetPicker("type")
+
+	assert.False(t, model.GetIsFacetPickerOpen())
+}
+
+func TestFacetPickerScrolls(t *testing.T) {
+	rows := []Row{}
+
+	for i := 0; i < facetPickerMaxLines+5; i++ {
+		rows = append(rows, NewRow(RowData{"id": i}))
+	}
+
+	model := New([]Column{NewColumn("id", "ID", 20).WithFiltered(true)}).
+		WithRows(rows).
+		Filtered(true).
+		Focused(true).
+		OpenFacetPicker("id")
+
+	for i := 0; i < facetPickerMaxLines+2; i++ {
+		model = typeKeys(model, tea.KeyMsg{Type: tea.KeyDown})
+	}
+
+	lines := model.facetPickerLines(20)
+
+	assert.Len(t, lines, facetPickerMaxLines+1)
+	assert.Equal(t, "  [ ] 3 (1)", lines[1])
+	assert.Equal(t, "> [ ] 12 (1)", lines[len(lines)-1])
+}
+
+func TestFacetFilterQueryPushdown(t *testing.T) {
+	source := testQueryableDataSource{
+		testDataSource: newTestDataSource(100),
+		lastQuery:      &DataSourceQuery{},
+	}
+
+	model := dataSourceTestModel(source).
+		Filtered(true).
+		WithFacetFilter("id", []string{"5"})
+
+	model.GetVisibleRows()
+
+	assert.Equal(t, map[string][]string{"id": {"5"}}, source.lastQuery.FacetFilters)
+}
+
+func TestFacetPickerReadsValuesOnce(t *testing.T) {
+	source := newTestDataSource(50)
+
+	model := New([]Column{NewColumn("id", "ID", 5).WithFiltered(true)}).
+		WithDataSource(source).
+		Filtered(true).
+		Focused(true).
+		WithPageSize(5).
+		OpenFacetPicker("id")
+
+	*source.fetched = 0
+
+	model.View()
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model.View()
+
+	// Only the rows in view are fetched to render them
+	assert.Less(t, *source.fetched, 50, "Should not fetch every row again")
+}
+
+func TestFacetPickerValuesFollowRows(t *testing.T) {
+	model := facetTestModel().OpenFacetPicker("type")
+
+	model = model.AppendRows(NewRow(RowData{"name": "Squirtle", "type": "Water"}))
+
+	assert.Contains(t, model.View(), "Water (1)")
+
+	model = model.WithRows([]Row{NewRow(RowData{"name": "Vulpix", "type": "Fire"})})
+
+	assert.Contains(t, model.View(), "> [ ] Fire (1)")
+	assert.NotContains(t, model.View(), "Water")
+
+	model = model.CloseFacetPicker()
+
+	assert.Nil(t, model.facetPickerValues)
+}
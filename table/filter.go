@@ -2,19 +2,244 @@ package table
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 )
 
+// FilterMode sets how the filter text is matched against rows.
+type FilterMode int
+
+const (
+	// FilterModeSubstring matches rows that contain the filter text, with
+	// support for column-scoped terms, numeric comparisons, negation, and
+	// quoted phrases.  This is the default.
+	FilterModeSubstring FilterMode = iota
+
+	// FilterModeFuzzy matches rows that contain the characters of each term
+	// in order, but not necessarily next to each other.  Matched characters
+	// are highlighted, and rows can be ordered by how well they match with
+	// WithFuzzySortByScore.
+	FilterModeFuzzy
+
+	// FilterModeRegex matches rows where any filterable column matches the
+	// filter text as a Go regular expression.  While the pattern is invalid,
+	// the error is shown in the footer and the rows matched by the last valid
+	// pattern stay visible.
+	FilterModeRegex
+)
+
+// The number of filter modes to cycle through with the FilterModeToggle key
+const filterModeCount = int(FilterModeRegex) + 1
+
+// String returns a short lowercase name for the filter mode.
+func (f FilterMode) String() string {
+	switch f {
+	case FilterModeSubstring:
+		return "substring"
+
+	case FilterModeFuzzy:
+		return "fuzzy"
+
+	case FilterModeRegex:
+		return "regex"
+
+	default:
+		return fmt.Sprintf("FilterMode(%d)", int(f))
+	}
+}
+
+// FilterFunc decides whether a row matches the filter text the user entered.
+// The columns are all of the table's columns, including ones that are not
+// filterable, so that the function can decide which ones to check.
+type FilterFunc func(columns []Column, row Row, filter string) bool
+
+type filterOperator int
+
+const (
+	filterOperatorContains filterOperator = iota
+	filterOperatorEqual
+	filterOperatorGreater
+	filterOperatorGreaterOrEqual
+	filterOperatorLess
+	filterOperatorLessOrEqual
+)
+
+// Longer operators must come first so that >= isn't read as >
+var filterOperatorSymbols = []struct {
+	symbol   string
+	operator filterOperator
+}{
+	{">=", filterOperatorGreaterOrEqual},
+	{"<=", filterOperatorLessOrEqual},
+	{":", filterOperatorContains},
+	{"=", filterOperatorEqual},
+	{">", filterOperatorGreater},
+	{"<", filterOperatorLess},
+}
+
+// filterTerm is a single space separated part of a filter.  All terms must
+// match for a row to match the filter.
+type filterTerm struct {
+	// Empty if the term applies to any filterable column
+	columnKey string
+
+	operator filterOperator
+
+	// Always lowercase
+	value string
+
+	negated bool
+}
+
 func (m Model) getFilteredRows(rows []Row) []Row {
-	filterInputValue := m.filterTextInput.Value()
-	if !m.filtered || filterInputValue == "" {
+	if !m.filtered {
+		return rows
+	}
+
+	rows = m.getColumnFilteredRows(rows)
+	rows = m.getFacetFilteredRows(rows)
+
+	filterInputValue := m.filterText()
+	if filterInputValue == "" {
+		return rows
+	}
+
+	if m.filterFunc != nil {
+		return m.getCustomFilteredRows(rows, filterInputValue)
+	}
+
+	switch m.filterMode {
+	case FilterModeFuzzy:
+		return m.getFuzzyFilteredRows(rows, filterInputValue)
+
+	case FilterModeRegex:
+		return m.getRegexFilteredRows(rows)
+	}
+
+	terms, err := parseFilter(m.columns, filterInputValue)
+
+	filteredRows := make([]Row, 0)
+
+	// An invalid filter matches nothing, and the error is shown in the footer
+	if err != nil {
+		return filteredRows
+	}
+
+	for _, row := range rows {
+		if isRowMatchedByTerms(m.columns, row, terms) {
+			filteredRows = append(filteredRows, row)
+		}
+	}
+
+	return filteredRows
+}
+
+func (m Model) getCustomFilteredRows(rows []Row, filter string) []Row {
+	filteredRows := make([]Row, 0)
+
+	for _, row := range rows {
+		if m.filterFunc(m.columns, row, filter) {
+			filteredRows = append(filteredRows, row)
+		}
+	}
+
+	return filteredRows
+}
+
+func (m Model) getRegexFilteredRows(rows []Row) []Row {
+	// Nothing valid has been entered yet, so nothing has been filtered out
+	if m.filterRegex == nil {
 		return rows
 	}
 
 	filteredRows := make([]Row, 0)
 
 	for _, row := range rows {
-		if isRowMatched(m.columns, row, filterInputValue) {
+		if isRowMatchedByRegex(m.columns, row, m.filterRegex) {
+			filteredRows = append(filteredRows, row)
+		}
+	}
+
+	return filteredRows
+}
+
+func isRowMatchedByRegex(columns []Column, row Row, pattern *regexp.Regexp) bool {
+	checkedAny := false
+
+	for _, column := range columns {
+		if !column.filterable {
+			continue
+		}
+
+		checkedAny = true
+
+		data, ok := row.Data[column.key]
+
+		if !ok {
+			continue
+		}
+
+		if styled, isStyled := data.(StyledCell); isStyled {
+			data = styled.Data
+		}
+
+		if pattern.MatchString(filterTargetString(data)) {
+			return true
+		}
+	}
+
+	return !checkedAny
+}
+
+// updateFilterRegex compiles the filter text when using FilterModeRegex.  If
+// the pattern is invalid, the last valid pattern is kept so that the visible
+// rows don't disappear while the user is still typing.
+func (m *Model) updateFilterRegex() {
+	value := m.filterText()
+
+	if m.filterMode != FilterModeRegex || value == "" {
+		m.filterRegex = nil
+		m.filterRegexError = nil
+
+		return
+	}
+
+	compiled, err := regexp.Compile(value)
+
+	if err != nil {
+		m.filterRegexError = err
+
+		return
+	}
+
+	m.filterRegex = compiled
+	m.filterRegexError = nil
+}
+
+// cycleFilterMode switches to the next filter mode, wrapping around.
+func (m *Model) cycleFilterMode() {
+	m.filterMode = FilterMode((int(m.filterMode) + 1) % filterModeCount)
+	m.invalidateFilterAndSort()
+	m.updateFilterRegex()
+	m.pageFirst()
+
+	m.appendUserEvent(UserEventFilterModeChanged{
+		FilterMode: m.filterMode,
+	})
+}
+
+func (m Model) getFuzzyFilteredRows(rows []Row, filter string) []Row {
+	terms := parseFuzzyFilter(filter)
+	filteredRows := make([]Row, 0)
+
+	for _, row := range rows {
+		score, matched := fuzzyRowScore(m.columns, row, terms)
+
+		if matched {
+			row.filterScore = score
 			filteredRows = append(filteredRows, row)
 		}
 	}
@@ -22,20 +247,88 @@ func (m Model) getFilteredRows(rows []Row) []Row {
 	return filteredRows
 }
 
+// sortRowsByFilterScore orders rows by how well they matched the filter, best
+// first.  Rows with the same score keep their existing order.
+func (m Model) sortRowsByFilterScore(rows []Row) []Row {
+	if !m.isSortedByFilterScore() {
+		return rows
+	}
+
+	sorted := make([]Row, len(rows))
+	copy(sorted, rows)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].filterScore > sorted[j].filterScore
+	})
+
+	return sorted
+}
+
+func (m Model) isSortedByFilterScore() bool {
+	return m.fuzzySortByScore && m.filterMode == FilterModeFuzzy && m.filterFunc == nil && m.filtered && m.filterText() != ""
+}
+
+// filterError returns any error with the current filter text, such as an
+// unknown column name or an invalid regular expression.
+func (m Model) filterError() error {
+	if !m.filtered || m.filterText() == "" || m.filterFunc != nil {
+		return nil
+	}
+
+	switch m.filterMode {
+	case FilterModeRegex:
+		return m.filterRegexError
+
+	case FilterModeFuzzy:
+		return nil
+	}
+
+	_, err := parseFilter(m.columns, m.filterText())
+
+	return err
+}
+
 func isRowMatched(columns []Column, row Row, filter string) bool {
 	if filter == "" {
 		return true
 	}
 
-	checkedAny := false
+	terms, err := parseFilter(columns, filter)
+
+	if err != nil {
+		return false
+	}
 
-	filterLower := strings.ToLower(filter)
+	return isRowMatchedByTerms(columns, row, terms)
+}
+
+func isRowMatchedByTerms(columns []Column, row Row, terms []filterTerm) bool {
+	for _, term := range terms {
+		// Empty values happen while the user is still typing, so ignore them
+		if term.value == "" {
+			continue
+		}
+
+		if isTermMatched(columns, row, term) == term.negated {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isTermMatched(columns []Column, row Row, term filterTerm) bool {
+	checkedAny := false
 
 	for _, column := range columns {
 		if !column.filterable {
 			continue
 		}
 
+		if term.columnKey != "" && column.key != term.columnKey {
+			continue
+		}
+
 		checkedAny = true
 
 		data, ok := row.Data[column.key]
@@ -44,28 +337,213 @@ func isRowMatched(columns []Column, row Row, filter string) bool {
 			continue
 		}
 
-		// Extract internal StyledCell data
-		switch dataV := data.(type) {
-		case StyledCell:
-			data = dataV.Data
+		if isDataMatched(data, term) {
+			return true
+		}
+	}
+
+	return !checkedAny
+}
+
+func isDataMatched(data interface{}, term filterTerm) bool {
+	// Extract internal StyledCell data
+	switch dataV := data.(type) {
+	case StyledCell:
+		data = dataV.Data
+	}
+
+	switch term.operator {
+	case filterOperatorContains:
+		return strings.Contains(strings.ToLower(filterTargetString(data)), term.value)
+
+	case filterOperatorEqual:
+		if compared, ok := compareAsNumbers(data, term.value); ok {
+			return compared == 0
+		}
+
+		return strings.ToLower(filterTargetString(data)) == term.value
+
+	case filterOperatorGreater, filterOperatorGreaterOrEqual, filterOperatorLess, filterOperatorLessOrEqual:
+		compared, ok := compareAsNumbers(data, term.value)
+
+		if !ok {
+			return false
 		}
 
-		var target string
-		switch dataV := data.(type) {
-		case string:
-			target = dataV
+		switch term.operator {
+		case filterOperatorGreater:
+			return compared > 0
+
+		case filterOperatorGreaterOrEqual:
+			return compared >= 0
 
-		case fmt.Stringer:
-			target = dataV.String()
+		case filterOperatorLess:
+			return compared < 0
 
 		default:
-			target = fmt.Sprintf("%v", data)
+			return compared <= 0
 		}
+	}
 
-		if strings.Contains(strings.ToLower(target), filterLower) {
-			return true
+	return false
+}
+
+// compareAsNumbers compares the data to the given value as numbers, returning
+// a negative number if the data is smaller, 0 if equal, or a positive number if
+// the data is larger.  Returns false if either is not a number.
+func compareAsNumbers(data interface{}, value string) (int, bool) {
+	dataNumber, isNumber := asNumber(data)
+
+	if !isNumber {
+		return 0, false
+	}
+
+	valueNumber, err := strconv.ParseFloat(value, 64)
+
+	if err != nil {
+		return 0, false
+	}
+
+	switch {
+	case dataNumber < valueNumber:
+		return -1, true
+
+	case dataNumber > valueNumber:
+		return 1, true
+
+	default:
+		return 0, true
+	}
+}
+
+func filterTargetString(data interface{}) string {
+	switch dataV := data.(type) {
+	case string:
+		return dataV
+
+	case fmt.Stringer:
+		return dataV.String()
+
+	default:
+		return fmt.Sprintf("%v", data)
+	}
+}
+
+// parseFilter splits the filter text into terms.  Terms are separated by
+// spaces unless quoted.  A term may be negated with a leading -, and may be
+// scoped to a column with column:value, or compared as a number with
+// column>value, column>=value, column<value, column<=value, or column=value.
+// Columns can be named by their key or title, and must be filterable.  Terms
+// that don't start with a name, such as 12:30, http://x, or -5, are searched
+// for as is.
+func parseFilter(columns []Column, filter string) ([]filterTerm, error) {
+	terms := []filterTerm{}
+
+	for _, raw := range splitFilterTerms(filter) {
+		term, err := parseFilterTerm(columns, raw)
+
+		if err != nil {
+			return nil, err
 		}
+
+		terms = append(terms, term)
 	}
 
-	return !checkedAny
+	return terms, nil
+}
+
+func splitFilterTerms(filter string) []string {
+	terms := []string{}
+	current := strings.Builder{}
+	inQuotes := false
+
+	for _, r := range filter {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+
+		case unicode.IsSpace(r) && !inQuotes:
+			if current.Len() > 0 {
+				terms = append(terms, current.String())
+				current.Reset()
+			}
+
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if current.Len() > 0 {
+		terms = append(terms, current.String())
+	}
+
+	return terms
+}
+
+func parseFilterTerm(columns []Column, raw string) (filterTerm, error) {
+	term := filterTerm{}
+
+	raw, term.negated = parseFilterNegation(raw)
+
+	// A name before an operator is a column name, unless the term is a URL
+	if index := strings.IndexAny(raw, ":=<>"); index > 0 && isFilterColumnName(raw[:index]) && !strings.HasPrefix(raw[index:], "://") {
+		columnKey, found := findFilterColumnKey(columns, raw[:index])
+
+		if !found {
+			return term, fmt.Errorf("unknown column %q", raw[:index])
+		}
+
+		term.columnKey = columnKey
+		raw = raw[index:]
+
+		for _, op := range filterOperatorSymbols {
+			if strings.HasPrefix(raw, op.symbol) {
+				term.operator = op.operator
+				raw = raw[len(op.symbol):]
+
+				break
+			}
+		}
+	}
+
+	term.value = strings.ToLower(strings.ReplaceAll(raw, `"`, ""))
+
+	return term, nil
+}
+
+// isFilterColumnName returns whether the text could be a column name in a
+// filter, which is a letter followed by any letters, digits, or underscores.
+func isFilterColumnName(text string) bool {
+	for i, r := range text {
+		if !unicode.IsLetter(r) && (i == 0 || (!unicode.IsDigit(r) && r != '_')) {
+			return false
+		}
+	}
+
+	return text != ""
+}
+
+// parseFilterNegation removes a leading - from the term and returns whether
+// the term is negated.  A negative number is searched for rather than negated.
+func parseFilterNegation(raw string) (string, bool) {
+	if _, err := strconv.ParseFloat(raw, 64); len(raw) > 1 && raw[0] == '-' && err != nil {
+		return raw[1:], true
+	}
+
+	return raw, false
+}
+
+func findFilterColumnKey(columns []Column, name string) (string, bool) {
+	for _, column := range columns {
+		if !column.filterable {
+			continue
+		}
+
+		if strings.EqualFold(column.key, name) || strings.EqualFold(column.title, name) {
+			return column.key, true
+		}
+	}
+
+	return "", false
 }
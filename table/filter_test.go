@@ -2,6 +2,7 @@ package table
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -114,6 +115,137 @@ func TestIsRowMatchedForNonStringer(t *testing.T) {
 		}), "3"))
 }
 
+func TestIsRowMatchedQuerySyntax(t *testing.T) {
+	columns := []Column{
+		NewColumn("name", "Name", 10).WithFiltered(true),
+		NewColumn("element", "Element", 10).WithFiltered(true),
+		NewColumn("attack", "ATK", 10).WithFiltered(true),
+		NewColumn("hp", "HP", 10).WithFiltered(true),
+		NewColumn("notes", "Notes", 10),
+	}
+
+	row := NewRow(RowData{
+		"name":    "Charmander",
+		"element": "Fire",
+		"attack":  52,
+		"hp":      NewStyledCell(39, lipgloss.NewStyle()),
+		"notes":   "water",
+	})
+
+	tests := []struct {
+		filter   string
+		expected bool
+	}{
+		{"name:char", true},
+		{"NAME:char", true},
+		{"Element:fire", true},
+		{"element:char", false},
+		{"attack>50", true},
+		{"attack>52", false},
+		{"attack>=52", true},
+		{"attack<52", false},
+		{"attack<=52", true},
+		{"attack=52", true},
+		{"atk=52.0", true},
+		{"hp<=30", false},
+		{"hp<40", true},
+		{"name>5", false},
+		{"attack>abc", false},
+		{"-water", true},
+		{"-fire", false},
+		{"-element:fire", false},
+		{"-element:water", true},
+		{"char fire", true},
+		{"char water", false},
+		{"fire  attack>50   hp<40", true},
+		{`"char mander"`, false},
+		{`name:"charm"`, true},
+		{`"e:f"`, false},
+		{"name:", true},
+		{"attack>", true},
+		{"-", false},
+		{"notes:water", false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, isRowMatched(columns, row, test.filter), "Wrong result for %q", test.filter)
+	}
+
+	phraseRow := NewRow(RowData{"name": "Mr. Mime", "element": "Psychic"})
+
+	assert.True(t, isRowMatched(columns, phraseRow, `"mr. mime"`))
+	assert.True(t, isRowMatched(columns, phraseRow, `-"mr. rime"`))
+	assert.False(t, isRowMatched(columns, phraseRow, `mr. rime`))
+}
+
+func TestFilterUnknownColumnShowsError(t *testing.T) {
+	columns := []Column{
+		NewColumn("name", "Name", 10).WithFiltered(true),
+		NewColumn("notes", "Notes", 30),
+	}
+
+	model := New(columns).
+		WithRows([]Row{NewRow(RowData{"name": "a"})}).
+		Filtered(true).
+		WithFilterInputValue("missing:a")
+
+	assert.Empty(t, model.GetVisibleRows())
+	assert.Contains(t, model.View(), `unknown column "missing"`)
+
+	// Not filterable, so can't be used in a filter either
+	model = model.WithFilterInputValue("notes:a")
+
+	assert.Contains(t, model.View(), `unknown column "notes"`)
+
+	model = model.WithFilterInputValue("name:a")
+
+	assert.Len(t, model.GetVisibleRows(), 1)
+	assert.NotContains(t, model.View(), "unknown column")
+}
+
+func TestFilterNonColumnPrefixMatchesLiterally(t *testing.T) {
+	columns := []Column{
+		NewColumn("name", "Name", 10).WithFiltered(true),
+		NewColumn("time", "Time", 10).WithFiltered(true),
+		NewColumn("url", "URL", 20).WithFiltered(true),
+		NewColumn("delta", "Delta", 5).WithFiltered(true),
+		NewColumn("notes", "Notes", 30),
+	}
+
+	rows := []Row{
+		NewRow(RowData{"name": "a", "time": "12:30", "url": "http://x", "delta": -5, "notes": "a"}),
+		NewRow(RowData{"name": "b", "time": "08:15", "url": "https://y", "delta": 5, "notes": "b"}),
+	}
+
+	tests := []struct {
+		filter   string
+		expected []string
+	}{
+		{"12:30", []string{"a"}},
+		{"http://x", []string{"a"}},
+		{"-5", []string{"a"}},
+		{"-12:30", []string{"b"}},
+		{"delta<0", []string{"a"}},
+		{"name:b", []string{"b"}},
+	}
+
+	for _, test := range tests {
+		model := New(columns).
+			WithRows(rows).
+			Filtered(true).
+			WithFilterInputValue(test.filter)
+
+		names := []string{}
+
+		for _, row := range model.GetVisibleRows() {
+			names = append(names, row.Data["name"].(string))
+		}
+
+		assert.Equal(t, test.expected, names, "Wrong rows for %q", test.filter)
+		assert.Nil(t, model.filterError(), "Unexpected error for %q", test.filter)
+	}
+}
+
 func TestGetFilteredRowsNoColumnFiltered(t *testing.T) {
 	columns := []Column{NewColumn("title", "title", 10)}
 	rows := []Row{
@@ -294,6 +426,169 @@ func TestFilterWithSetValue(t *testing.T) {
 	assert.Len(t, filteredRows, 3)
 }
 
+func TestFilterFuncReplacesMatching(t *testing.T) {
+	columns := []Column{NewColumn("name", "Name", 10).WithFiltered(true)}
+	rows := []Row{
+		NewRow(RowData{"name": "Pikachu", "trainer": "Ash"}),
+		NewRow(RowData{"name": "Starmie", "trainer": "Misty"}),
+		NewRow(RowData{"name": "Onix", "trainer": "Brock"}),
+	}
+
+	// Search hidden data that isn't a column
+	filterByTrainer := func(columns []Column, row Row, filter string) bool {
+		return strings.EqualFold(row.Data["trainer"].(string), filter)
+	}
+
+	model := New(columns).
+		WithRows(rows).
+		Filtered(true).
+		WithFilterFunc(filterByTrainer).
+		WithFilterInputValue("misty")
+
+	visible := model.GetVisibleRows()
+
+	assert.Len(t, visible, 1)
+	assert.Equal(t, "Starmie", visible[0].Data["name"])
+
+	// The query syntax doesn't apply, so there's no error in the footer
+	model = model.WithFilterInputValue("unknown:ash")
+
+	assert.Empty(t, model.GetVisibleRows())
+	assert.NotContains(t, model.View(), "unknown column")
+
+	// Clearing the filter shows every row without calling the function
+	model = model.WithFilterInputValue("")
+
+	assert.Len(t, model.GetVisibleRows(), 3)
+
+	// Setting it back to nil uses the built-in matching again
+	model = model.WithFilterFunc(nil).WithFilterInputValue("onix")
+
+	assert.Len(t, model.GetVisibleRows(), 1)
+}
+
+func TestFilterFuncTypingResetsPage(t *testing.T) {
+	columns := []Column{NewColumn("name", "Name", 10).WithFiltered(true)}
+	rows := []Row{
+		NewRow(RowData{"name": "a"}),
+		NewRow(RowData{"name": "b"}),
+		NewRow(RowData{"name": "c"}),
+	}
+
+	calls := 0
+	matchAll := func(columns []Column, row Row, filter string) bool {
+		calls++
+
+		return true
+	}
+
+	model := New(columns).
+		WithRows(rows).
+		Filtered(true).
+		Focused(true).
+		WithPageSize(1).
+		WithFilterFunc(matchAll)
+
+	model.pageDown()
+	assert.Equal(t, 2, model.CurrentPage(), "Should start on second page for test")
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+
+	assert.Equal(t, 1, model.CurrentPage(), "Did not go back to first page")
+	assert.Equal(t, "x", model.GetCurrentFilter())
+
+	model.GetVisibleRows()
+	calls = 0
+	model.GetVisibleRows()
+
+	assert.Equal(t, 0, calls, "Should use the visible row cache")
+}
+
+func regexFilterTestModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 50).WithFiltered(true),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "Pikachu"}),
+		NewRow(RowData{"name": "Raichu"}),
+		NewRow(RowData{"name": "Pichu"}),
+	}).Filtered(true).Focused(true).WithFilterMode(FilterModeRegex)
+}
+
+func TestFilterRegexMatchesRows(t *testing.T) {
+	model := regexFilterTestModel().WithFilterInputValue("^P.*chu$")
+
+	assert.Equal(t, []string{"Pikachu", "Pichu"}, visibleNames(model))
+	assert.Equal(t, FilterModeRegex, model.GetFilterMode())
+	assert.Contains(t, model.View(), "[regex]")
+}
+
+func TestFilterRegexInvalidKeepsLastResult(t *testing.T) {
+	model := regexFilterTestModel()
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+
+	for _, r := range "^(Ra" {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	// Nothing valid yet, so nothing is filtered out
+	assert.Len(t, model.GetVisibleRows(), 3)
+	assert.Contains(t, model.View(), "error parsing regexp")
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{')'}})
+
+	assert.Equal(t, []string{"Raichu"}, visibleNames(model))
+	assert.NotContains(t, model.View(), "error parsing regexp")
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'['}})
+
+	assert.Equal(t, []string{"Raichu"}, visibleNames(model), "Should keep the last valid result")
+	assert.Contains(t, model.View(), "`[`")
+}
+
+func TestFilterModeToggleCycles(t *testing.T) {
+	model := regexFilterTestModel().WithFilterMode(FilterModeSubstring).WithFilterInputValue("P.*u")
+
+	assert.Empty(t, model.GetVisibleRows())
+
+	hitToggle := func() {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	}
+
+	hitToggle()
+
+	assert.Equal(t, FilterModeFuzzy, model.GetFilterMode())
+	assert.Equal(t, []UserEvent{UserEventFilterModeChanged{FilterMode: FilterModeFuzzy}}, model.GetLastUpdateUserEvents())
+
+	hitToggle()
+
+	assert.Equal(t, FilterModeRegex, model.GetFilterMode())
+	assert.Equal(t, []string{"Pikachu", "Pichu"}, visibleNames(model))
+
+	hitToggle()
+
+	assert.Equal(t, FilterModeSubstring, model.GetFilterMode())
+	assert.Empty(t, model.GetVisibleRows())
+}
+
+func TestFilterModeToggleWhileTyping(t *testing.T) {
+	model := regexFilterTestModel().WithFilterMode(FilterModeSubstring)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'^'}})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+
+	assert.Empty(t, model.GetVisibleRows())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+
+	assert.True(t, model.GetIsFilterInputFocused())
+	assert.Equal(t, "^R", model.GetCurrentFilter())
+	assert.Equal(t, []string{"Raichu"}, visibleNames(model))
+}
+
 func BenchmarkFilteredScrolling(b *testing.B) {
 	// Scrolling through a filtered table with many rows should be quick
 	// https://github.com/Evertras/bubble-table/issues/135
@@ -0,0 +1,142 @@
+package table
+
+// pinToNewestRow moves the highlight to the last visible row, scrolling or
+// changing pages as needed.
+func (m *Model) pinToNewestRow() {
+	m.rowCursorIndex = max(m.visibleRowCount()-1, 0)
+	m.currentPage = m.expectedPageForRowIndex(m.rowCursorIndex)
+	m.updateVerticalScroll()
+}
+
+// startFollowingFromUser resumes following the newest row if follow mode is
+// enabled.
+func (m *Model) startFollowingFromUser() {
+	if !m.followMode {
+		return
+	}
+
+	m.pinToNewestRow()
+
+	if m.following {
+		return
+	}
+
+	m.following = true
+
+	m.appendUserEvent(UserEventFollowModeChanged{
+		IsFollowing: true,
+	})
+}
+
+// stopFollowingIfMoved stops following the newest row if the user has moved
+// the highlight away from it.
+func (m *Model) stopFollowingIfMoved() {
+	if !m.following {
+		return
+	}
+
+	totalRows := m.visibleRowCount()
+
+	if totalRows == 0 || m.rowCursorIndex == totalRows-1 {
+		return
+	}
+
+	m.following = false
+
+	m.appendUserEvent(UserEventFollowModeChanged{
+		IsFollowing: false,
+	})
+}
+
+// WithFollowMode sets whether the table should follow the newest row, which is
+// useful for streaming data such as logs.  While following, the highlight and
+// viewport stay on the last row as rows are added.  If the user moves away from
+// the last row, the table stops following until the user presses the PageLast
+// key.  Enabling follow mode starts following immediately.
+func (m Model) WithFollowMode(enabled bool) Model {
+	m.followMode = enabled
+	m.following = enabled
+
+	if m.following {
+		m.pinToNewestRow()
+	}
+
+	return m
+}
+
+// WithMaxRows limits how many rows are kept when adding rows with AppendRows.
+// When the limit is exceeded, the oldest rows are dropped first.  This is not a
+// ring buffer, since the kept rows are copied on every append.  A limit of 0
+// means there is no limit, which is the default.
+func (m Model) WithMaxRows(maxRows int) Model {
+	m.maxRows = maxRows
+
+	return m
+}
+
+// AppendRows adds the given rows after any existing rows.  If a limit was set
+// with WithMaxRows, the oldest rows are dropped to stay within the limit.  If
+// the table is following the newest row, the highlight moves to the last row.
+// Otherwise the highlight stays on the same row unless it was dropped.  Rows
+// can't be appended to a data source.
+//
+// Only the new rows are filtered and sorted, but every call still copies all of
+// the kept rows, so each call takes time proportional to the size of the table.
+// When rows arrive quickly, append them in batches, such as once per tick,
+// rather than one at a time.
+func (m Model) AppendRows(rows ...Row) Model {
+	if m.dataSource != nil {
+		return m
+	}
+
+	var highlightedIdentity interface{}
+
+	if m.rowCursorIndex < m.visibleRowCount() {
+		highlightedIdentity = rowIdentity(m.visibleRowAt(m.rowCursorIndex))
+	}
+
+	total := len(m.rows) + len(rows)
+	dropped := 0
+
+	if m.maxRows > 0 && total > m.maxRows {
+		dropped = total - m.maxRows
+		total = m.maxRows
+	}
+
+	// Always copy to avoid sharing the backing array with older models
+	appended := make([]Row, 0, total)
+
+	if dropped < len(m.rows) {
+		appended = append(appended, m.rows[dropped:]...)
+		appended = append(appended, rows...)
+	} else {
+		appended = append(appended, rows[dropped-len(m.rows):]...)
+	}
+
+	highlightedID := m.highlightedRowID()
+
+	// Only the new rows need to be filtered and sorted into the visible rows
+	m.invalidateAppendedRows(len(rows), total)
+
+	m = m.replaceRows(appended, highlightedID)
+
+	if !m.following && highlightedIdentity != nil {
+		m.highlightRowByIdentity(highlightedIdentity)
+	}
+
+	return m
+}
+
+// highlightRowByIdentity moves the highlight to the visible row with the given
+// identity, if there is one.
+func (m *Model) highlightRowByIdentity(identity interface{}) {
+	for i, row := range m.GetVisibleRows() {
+		if rowIdentity(row) == identity {
+			m.rowCursorIndex = i
+			m.currentPage = m.expectedPageForRowIndex(i)
+			m.updateVerticalScroll()
+
+			return
+		}
+	}
+}
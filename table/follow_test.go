@@ -0,0 +1,130 @@
+package table
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func followTestRows(start, count int) []Row {
+	rows := []Row{}
+
+	for i := start; i < start+count; i++ {
+		rows = append(rows, NewRow(RowData{"line": fmt.Sprintf("line %d", i)}))
+	}
+
+	return rows
+}
+
+func followTestModel() Model {
+	return New([]Column{
+		NewColumn("line", "Line", 10),
+	}).Focused(true)
+}
+
+func TestAppendRows(t *testing.T) {
+	model := followTestModel().WithRows(followTestRows(0, 2))
+	original := model
+
+	model = model.AppendRows(followTestRows(2, 2)...)
+
+	assert.Equal(t, 4, model.TotalRows())
+	assert.Equal(t, "line 3", model.GetVisibleRows()[3].Data["line"])
+	assert.Equal(t, 2, original.TotalRows(), "Should not modify the original model")
+	assert.Equal(t, 0, model.GetHighlightedRowIndex(), "Should not move without follow mode")
+}
+
+func TestAppendRowsWithMaxRows(t *testing.T) {
+	model := followTestModel().WithRows(followTestRows(0, 3)).WithMaxRows(4).WithHighlightedRow(2)
+
+	model = model.AppendRows(followTestRows(3, 2)...)
+
+	assert.Equal(t, 4, model.TotalRows())
+	assert.Equal(t, "line 1", model.GetVisibleRows()[0].Data["line"])
+	assert.Equal(t, "line 2", model.HighlightedRow().Data["line"], "Should stay on the same row")
+
+	// More rows than the limit at once
+	model = model.AppendRows(followTestRows(5, 6)...)
+
+	assert.Equal(t, 4, model.TotalRows())
+	assert.Equal(t, "line 7", model.GetVisibleRows()[0].Data["line"])
+	assert.Equal(t, "line 10", model.GetVisibleRows()[3].Data["line"])
+}
+
+func TestFollowModeStaysOnNewestRow(t *testing.T) {
+	model := followTestModel().WithMaxHeight(7).WithFollowMode(true)
+
+	assert.True(t, model.GetIsFollowing())
+
+	model = model.AppendRows(followTestRows(0, 10)...)
+
+	assert.Equal(t, 9, model.GetHighlightedRowIndex())
+
+	start, end := model.VisibleIndices()
+	assert.Equal(t, 7, start)
+	assert.Equal(t, 9, end)
+
+	model = model.AppendRows(followTestRows(10, 1)...)
+
+	assert.Equal(t, 10, model.GetHighlightedRowIndex())
+
+	_, end = model.VisibleIndices()
+	assert.Equal(t, 10, end)
+}
+
+func TestFollowModeStopsAndResumes(t *testing.T) {
+	model := followTestModel().WithFollowMode(true).AppendRows(followTestRows(0, 5)...)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyUp})
+
+	assert.False(t, model.GetIsFollowing())
+	assert.Contains(t, model.GetLastUpdateUserEvents(), UserEventFollowModeChanged{IsFollowing: false})
+
+	model = model.AppendRows(followTestRows(5, 1)...)
+
+	assert.Equal(t, 3, model.GetHighlightedRowIndex(), "Should stay put while not following")
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnd})
+
+	assert.True(t, model.GetIsFollowing())
+	assert.Equal(t, 5, model.GetHighlightedRowIndex())
+	assert.Contains(t, model.GetLastUpdateUserEvents(), UserEventFollowModeChanged{IsFollowing: true})
+
+	// Already following, so no new event
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnd})
+
+	assert.NotContains(t, model.GetLastUpdateUserEvents(), UserEventFollowModeChanged{IsFollowing: true})
+}
+
+func TestFollowModeWithPagination(t *testing.T) {
+	model := followTestModel().WithPageSize(3).WithFollowMode(true)
+
+	model = model.AppendRows(followTestRows(0, 7)...)
+
+	assert.Equal(t, 6, model.GetHighlightedRowIndex())
+	assert.Equal(t, 3, model.CurrentPage())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+
+	assert.False(t, model.GetIsFollowing())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnd})
+
+	assert.True(t, model.GetIsFollowing())
+	assert.Equal(t, 6, model.GetHighlightedRowIndex(), "Should go to the last row, not the start of the page")
+}
+
+func TestFollowModeDisabledNoEvents(t *testing.T) {
+	model := followTestModel().AppendRows(followTestRows(0, 5)...)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyUp})
+
+	assert.False(t, model.GetIsFollowing())
+
+	for _, event := range model.GetLastUpdateUserEvents() {
+		assert.IsType(t, UserEventHighlightedIndexChanged{}, event)
+	}
+}
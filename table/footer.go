@@ -3,10 +3,12 @@ package table
 import (
 	"fmt"
 	"strings"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 func (m Model) hasFooter() bool {
-	return m.footerVisible && (m.staticFooter != "" || m.pageSize != 0 || m.filtered)
+	return m.isFacetPickerOpen() || m.footerVisible && (m.staticFooter != "" || m.pageSize != 0 || m.filtered || m.cellEditError != nil)
 }
 
 func (m Model) renderFooter(width int, includeTop bool) string {
@@ -22,14 +24,34 @@ func (m Model) renderFooter(width int, includeTop bool) string {
 		styleFooter.BorderTop(true)
 	}
 
-	if m.staticFooter != "" {
+	if m.isFacetPickerOpen() {
+		lines := m.facetPickerLines(width - borderAdjustment)
+
+		return styleFooter.Align(lipgloss.Left).Render(strings.Join(lines, "\n"))
+	}
+
+	if m.staticFooter != "" && m.cellEditError == nil {
 		return styleFooter.Render(m.staticFooter)
 	}
 
 	sections := []string{}
 
-	if m.filtered && (m.filterTextInput.Focused() || m.filterTextInput.Value() != "") {
+	if m.cellEditError != nil {
+		sections = append(sections, m.cellEditError.Error())
+	} else if m.filtered && (m.filterTextInput.Focused() || m.filterTextInput.Value() != "") {
+		if m.filterMode != FilterModeSubstring && m.filterFunc == nil {
+			sections = append(sections, fmt.Sprintf("[%s]", m.filterMode))
+		}
+
 		sections = append(sections, m.filterTextInput.View())
+
+		if err := m.filterError(); err != nil {
+			sections = append(sections, err.Error())
+		}
+	}
+
+	if m.isWaitingForVisibleRows() {
+		sections = append(sections, asyncFilterSortIndicator)
 	}
 
 	// paged feature enabled
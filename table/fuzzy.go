@@ -0,0 +1,192 @@
+package table
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	fuzzyScoreMatch       = 1
+	fuzzyScoreConsecutive = 5
+	fuzzyScoreWordStart   = 3
+	fuzzyPenaltyGap       = 1
+	fuzzyPenaltyGapMax    = 5
+)
+
+// parseFuzzyFilter splits the filter into lowercase terms.  Each term must
+// fuzzy match some filterable column for a row to match.
+func parseFuzzyFilter(filter string) [][]rune {
+	terms := [][]rune{}
+
+	for _, field := range strings.Fields(filter) {
+		terms = append(terms, toLowerRunes(field))
+	}
+
+	return terms
+}
+
+func toLowerRunes(str string) []rune {
+	runes := []rune(str)
+
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+
+	return runes
+}
+
+// fuzzyMatch checks whether all runes in the pattern appear in order in the
+// target, which must already be lowercase.  Returns the score of the best match
+// found and the rune indices in the target that matched.  Higher scores mean
+// the match is more likely to be what the user wanted, with consecutive runes
+// and runes at the start of words scoring higher.
+func fuzzyMatch(pattern, target []rune) (score int, positions []int, matched bool) {
+	if len(pattern) == 0 {
+		return 0, nil, true
+	}
+
+	for start := range target {
+		if target[start] != pattern[0] {
+			continue
+		}
+
+		candidateScore, candidatePositions, ok := fuzzyMatchFrom(pattern, target, start)
+
+		if !ok {
+			// Starting later can only have fewer runes left to match
+			break
+		}
+
+		if !matched || candidateScore > score {
+			score = candidateScore
+			positions = candidatePositions
+			matched = true
+		}
+	}
+
+	return score, positions, matched
+}
+
+// fuzzyMatchFrom greedily matches the pattern starting at the given index.
+func fuzzyMatchFrom(pattern, target []rune, start int) (int, []int, bool) {
+	positions := make([]int, 0, len(pattern))
+	score := 0
+	patternIndex := 0
+
+	for i := start; i < len(target) && patternIndex < len(pattern); i++ {
+		if target[i] != pattern[patternIndex] {
+			continue
+		}
+
+		score += fuzzyScoreMatch
+
+		if isFuzzyWordStart(target, i) {
+			score += fuzzyScoreWordStart
+		}
+
+		if len(positions) > 0 {
+			previous := positions[len(positions)-1]
+
+			if previous == i-1 {
+				score += fuzzyScoreConsecutive
+			} else {
+				score -= min((i-previous-1)*fuzzyPenaltyGap, fuzzyPenaltyGapMax)
+			}
+		}
+
+		positions = append(positions, i)
+		patternIndex++
+	}
+
+	if patternIndex < len(pattern) {
+		return 0, nil, false
+	}
+
+	return score, positions, true
+}
+
+func isFuzzyWordStart(target []rune, index int) bool {
+	if index == 0 {
+		return true
+	}
+
+	previous := target[index-1]
+
+	return !unicode.IsLetter(previous) && !unicode.IsDigit(previous)
+}
+
+// fuzzyRowScore returns the total score of the best match of each term in the
+// row's filterable columns, or false if any term does not match.
+func fuzzyRowScore(columns []Column, row Row, terms [][]rune) (int, bool) {
+	targets := [][]rune{}
+	checkedAny := false
+
+	for _, column := range columns {
+		if !column.filterable {
+			continue
+		}
+
+		checkedAny = true
+
+		data, ok := row.Data[column.key]
+
+		if !ok {
+			continue
+		}
+
+		if styled, isStyled := data.(StyledCell); isStyled {
+			data = styled.Data
+		}
+
+		targets = append(targets, toLowerRunes(filterTargetString(data)))
+	}
+
+	if !checkedAny {
+		return 0, true
+	}
+
+	total := 0
+
+	for _, term := range terms {
+		best := 0
+		found := false
+
+		for _, target := range targets {
+			score, _, matched := fuzzyMatch(term, target)
+
+			if matched && (!found || score > best) {
+				best = score
+				found = true
+			}
+		}
+
+		if !found {
+			return 0, false
+		}
+
+		total += best
+	}
+
+	return total, true
+}
+
+// fuzzyMatchedRunes returns which runes in the given text are part of the best
+// fuzzy match of any of the terms.
+func fuzzyMatchedRunes(text string, terms [][]rune) []bool {
+	target := toLowerRunes(text)
+	matched := make([]bool, len(target))
+
+	for _, term := range terms {
+		_, positions, ok := fuzzyMatch(term, target)
+
+		if !ok {
+			continue
+		}
+
+		for _, position := range positions {
+			matched[position] = true
+		}
+	}
+
+	return matched
+}
@@ -0,0 +1,180 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   string
+		target    string
+		matched   bool
+		positions []int
+	}{
+		{
+			name:    "Empty pattern",
+			pattern: "",
+			target:  "anything",
+			matched: true,
+		},
+		{
+			name:      "Exact",
+			pattern:   "abc",
+			target:    "abc",
+			matched:   true,
+			positions: []int{0, 1, 2},
+		},
+		{
+			name:      "Gaps",
+			pattern:   "pkch",
+			target:    "pikachu",
+			matched:   true,
+			positions: []int{0, 2, 4, 5},
+		},
+		{
+			name:      "Prefers word start",
+			pattern:   "ch",
+			target:    "catch chu",
+			matched:   true,
+			positions: []int{6, 7},
+		},
+		{
+			name:    "Out of order",
+			pattern: "ba",
+			target:  "ab",
+			matched: false,
+		},
+		{
+			name:    "Missing rune",
+			pattern: "abz",
+			target:  "abc",
+			matched: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, positions, matched := fuzzyMatch([]rune(test.pattern), []rune(test.target))
+
+			assert.Equal(t, test.matched, matched)
+			assert.Equal(t, test.positions, positions)
+		})
+	}
+}
+
+func TestFuzzyMatchScoresConsecutiveHigher(t *testing.T) {
+	consecutive, _, _ := fuzzyMatch([]rune("pika"), []rune("pikachu"))
+	scattered, _, _ := fuzzyMatch([]rune("pika"), []rune("pxixkxa"))
+
+	assert.Greater(t, consecutive, scattered)
+}
+
+func fuzzyTestModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 10).WithFiltered(true),
+		NewColumn("type", "Type", 8).WithFiltered(true),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "Bulbasaur", "type": "Grass"}),
+		NewRow(RowData{"name": "Pikachu", "type": "Electric"}),
+		NewRow(RowData{"name": "Charmander", "type": "Fire"}),
+		NewRow(RowData{"name": "Pichu", "type": "Electric"}),
+	}).WithFilterMode(FilterModeFuzzy).Filtered(true)
+}
+
+func TestFuzzyFilterMatchesRows(t *testing.T) {
+	model := fuzzyTestModel().WithFilterInputValue("pch")
+
+	assert.Equal(t, []string{"Pikachu", "Pichu"}, visibleNames(model))
+	assert.Equal(t, FilterModeFuzzy, model.GetFilterMode())
+}
+
+func TestFuzzyFilterTermsMatchAnyColumn(t *testing.T) {
+	model := fuzzyTestModel().WithFilterInputValue("elc pk")
+
+	assert.Equal(t, []string{"Pikachu"}, visibleNames(model))
+}
+
+func TestFuzzyFilterIgnoresQuerySyntax(t *testing.T) {
+	model := fuzzyTestModel().WithFilterInputValue("nope:x")
+
+	assert.Empty(t, visibleNames(model))
+	assert.Nil(t, model.filterError())
+}
+
+func TestFuzzyFilterSortByScore(t *testing.T) {
+	model := fuzzyTestModel().WithFilterInputValue("pchu")
+
+	assert.Equal(t, []string{"Pikachu", "Pichu"}, visibleNames(model))
+
+	model = model.WithFuzzySortByScore(true)
+
+	assert.Equal(t, []string{"Pichu", "Pikachu"}, visibleNames(model))
+
+	// Only applies while filtering
+	model = model.WithFilterInputValue("")
+
+	assert.Equal(t, []string{"Bulbasaur", "Pikachu", "Charmander", "Pichu"}, visibleNames(model))
+}
+
+func TestFuzzyFilterHighlightsMatches(t *testing.T) {
+	matchStyle := lipgloss.NewStyle().Bold(true)
+
+	model := New([]Column{
+		NewColumn("name", "Name", 6).WithFiltered(true),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "Pikachu"}),
+	}).WithFilterMode(FilterModeFuzzy).
+		Filtered(true).
+		WithFilterInputValue("pk").
+		WithFilterMatchStyle(matchStyle)
+
+	row := model.GetVisibleRows()[0]
+	rendered := model.renderRowColumnData(row, model.columns[0], lipgloss.NewStyle(), lipgloss.NewStyle(), false)
+
+	// Truncation still applies, and only the matched runes are styled
+	expected := matchStyle.Render("P") + "i" + matchStyle.Render("k") + "ac…"
+
+	assert.Equal(t, expected, rendered)
+}
+
+func TestFuzzyFilterHighlightKeepsCellStyle(t *testing.T) {
+	matchStyle := lipgloss.NewStyle().Underline(true)
+	cellStyle := lipgloss.NewStyle().Italic(true)
+
+	model := New([]Column{
+		NewColumn("name", "Name", 7).WithFiltered(true),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": NewStyledCell("Pichu", cellStyle)}),
+	}).WithFilterMode(FilterModeFuzzy).
+		Filtered(true).
+		WithFilterInputValue("pc").
+		WithFilterMatchStyle(matchStyle)
+
+	row := model.GetVisibleRows()[0]
+	rendered := model.renderRowColumnData(row, model.columns[0], lipgloss.NewStyle(), lipgloss.NewStyle(), false)
+
+	highlighted := matchStyle.Copy().Inherit(cellStyle)
+
+	assert.Contains(t, rendered, highlighted.Render("P"))
+	assert.Contains(t, rendered, cellStyle.Render("i"))
+	assert.Contains(t, rendered, highlighted.Render("c"))
+	assert.Contains(t, rendered, cellStyle.Render("hu"))
+}
+
+func TestFuzzyFilterHighlightIgnoresUnfilterableColumns(t *testing.T) {
+	model := New([]Column{
+		NewColumn("name", "Name", 7).WithFiltered(true),
+		NewColumn("other", "Other", 7),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "Pichu", "other": "Pichu"}),
+	}).WithFilterMode(FilterModeFuzzy).
+		Filtered(true).
+		WithFilterInputValue("pc")
+
+	assert.NotNil(t, model.filterMatchedRunes(model.columns[0], "Pichu", "Pichu"))
+	assert.Nil(t, model.filterMatchedRunes(model.columns[1], "Pichu", "Pichu"))
+}
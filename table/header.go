@@ -1,6 +1,59 @@
 package table
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/ansi"
+)
+
+// sortIndicator returns the indicator to show in the header of the given
+// column, or an empty string if the column is not sorted.  The priority is
+// only shown when sorting by more than one column, where 1 is the column that
+// was sorted last and therefore has the most effect.
+func (m Model) sortIndicator(columnKey string) string {
+	for i, sortColumn := range m.sortOrder {
+		if sortColumn.ColumnKey != columnKey {
+			continue
+		}
+
+		indicator := m.sortIndicatorAsc
+
+		if sortColumn.Direction == SortDirectionDesc {
+			indicator = m.sortIndicatorDesc
+		}
+
+		if indicator != "" && len(m.sortOrder) > 1 {
+			indicator += fmt.Sprintf("%d", len(m.sortOrder)-i)
+		}
+
+		return indicator
+	}
+
+	return ""
+}
+
+// headerTitle returns the column title fit to the column width, including any
+// sort indicator.  If there's room the indicator is separated from the title
+// by a space, otherwise the title is truncated to make room for it.
+func (m Model) headerTitle(column Column) string {
+	indicator := m.sortIndicator(column.key)
+	indicatorWidth := ansi.PrintableRuneWidth(indicator)
+
+	if indicator == "" || indicatorWidth >= column.width {
+		return limitStr(column.title, column.width)
+	}
+
+	if spaced := limitStr(column.title, column.width-indicatorWidth-1); spaced == column.title {
+		return spaced + " " + indicator
+	}
+
+	return limitStr(column.title, column.width-indicatorWidth) + indicator
+}
+
+func (m Model) isHeaderHighlighted(columnIndex int) bool {
+	return m.focused && m.cellCursor && columnIndex == m.columnCursorIndex
+}
 
 // This is long and could use some refactoring in the future, but unsure of how
 // to pick it apart right now.
@@ -16,7 +69,11 @@ func (m Model) renderHeaders() string {
 	renderHeader := func(column Column, borderStyle lipgloss.Style) string {
 		borderStyle = borderStyle.Inherit(column.style).Inherit(m.baseStyle)
 
-		headerSection := limitStr(column.title, column.width)
+		headerSection := m.headerTitle(column)
+
+		if m.isColumnFilterRowVisible() {
+			headerSection += "\n" + m.columnFilterText(column)
+		}
 
 		return borderStyle.Render(headerSection)
 	}
@@ -51,9 +108,14 @@ func (m Model) renderHeaders() string {
 			borderStyle = headerStyles.right.Copy()
 		}
 
+		// Shows which column the sort keys apply to
+		if m.isHeaderHighlighted(columnIndex) {
+			borderStyle = borderStyle.Inherit(m.highlightStyle)
+		}
+
 		rendered := renderHeader(column, borderStyle)
 
-		if m.maxTotalWidth != 0 {
+		if m.maxTableWidth() != 0 {
 			renderedWidth := lipgloss.Width(rendered)
 
 			const (
@@ -61,16 +123,16 @@ func (m Model) renderHeaders() string {
 				overflowColWidth = 2
 			)
 
-			targetWidth := m.maxTotalWidth - overflowColWidth
+			targetWidth := m.maxTableWidth() - overflowColWidth
 
 			if columnIndex == len(m.columns)-1 {
 				// If this is the last header, we don't need to account for the
 				// overflow arrow column
-				targetWidth = m.maxTotalWidth
+				targetWidth = m.maxTableWidth()
 			}
 
 			if totalRenderedWidth+renderedWidth > targetWidth {
-				overflowWidth := m.maxTotalWidth - totalRenderedWidth - borderAdjustment
+				overflowWidth := m.maxTableWidth() - totalRenderedWidth - borderAdjustment
 				overflowStyle := genOverflowStyle(headerStyles.right, overflowWidth)
 				overflowColumn := genOverflowColumnRight(overflowWidth)
 
@@ -0,0 +1,187 @@
+package table
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// filterMatchedRunes returns which runes of the given cell text match the
+// current filter, or nil if nothing in the cell should be highlighted.  The data
+// is the cell's underlying value that the text was rendered from.
+func (m Model) filterMatchedRunes(column Column, data interface{}, text string) []bool {
+	// There's no way to know what a custom filter function matched
+	if !m.filtered || !column.filterable || m.filterText() == "" || m.filterFunc != nil {
+		return nil
+	}
+
+	switch m.filterMode {
+	case FilterModeFuzzy:
+		return fuzzyMatchedRunes(text, parseFuzzyFilter(m.filterText()))
+
+	case FilterModeRegex:
+		if !m.filterMatchHighlighting || m.filterRegex == nil {
+			return nil
+		}
+
+		return regexMatchedRunes(text, m.filterRegex)
+
+	default:
+		if !m.filterMatchHighlighting {
+			return nil
+		}
+
+		terms, err := parseFilter(m.columns, m.filterText())
+
+		if err != nil {
+			return nil
+		}
+
+		return substringMatchedRunes(column, data, text, terms)
+	}
+}
+
+// substringMatchedRunes returns which runes in the given text are matched by
+// any of the terms that apply to the column.  Terms that compare the whole
+// value, such as numeric comparisons, match the entire text.  Negated terms
+// never highlight anything, since they match by not being there.
+func substringMatchedRunes(column Column, data interface{}, text string, terms []filterTerm) []bool {
+	target := toLowerRunes(text)
+	matched := make([]bool, len(target))
+
+	for _, term := range terms {
+		if term.negated || term.value == "" {
+			continue
+		}
+
+		if term.columnKey != "" && term.columnKey != column.key {
+			continue
+		}
+
+		if term.operator != filterOperatorContains {
+			if isDataMatched(data, term) {
+				for i := range matched {
+					matched[i] = true
+				}
+			}
+
+			continue
+		}
+
+		value := []rune(term.value)
+
+		for start := 0; start+len(value) <= len(target); start++ {
+			if string(target[start:start+len(value)]) != term.value {
+				continue
+			}
+
+			for i := start; i < start+len(value); i++ {
+				matched[i] = true
+			}
+		}
+	}
+
+	return matched
+}
+
+// regexMatchedRunes returns which runes in the given text are part of any
+// match of the pattern.
+func regexMatchedRunes(text string, pattern *regexp.Regexp) []bool {
+	matched := make([]bool, utf8.RuneCountInString(text))
+
+	for _, match := range pattern.FindAllStringIndex(text, -1) {
+		start := utf8.RuneCountInString(text[:match[0]])
+		end := start + utf8.RuneCountInString(text[match[0]:match[1]])
+
+		for i := start; i < end; i++ {
+			matched[i] = true
+		}
+	}
+
+	return matched
+}
+
+// textStyle returns only the parts of the style that affect how text looks,
+// such as color, so that it can be applied to parts of a cell without any
+// padding, borders, or alignment.
+func textStyle(style lipgloss.Style) lipgloss.Style {
+	return lipgloss.NewStyle().
+		Bold(style.GetBold()).
+		Italic(style.GetItalic()).
+		Underline(style.GetUnderline()).
+		Strikethrough(style.GetStrikethrough()).
+		Reverse(style.GetReverse()).
+		Blink(style.GetBlink()).
+		Faint(style.GetFaint()).
+		Foreground(style.GetForeground()).
+		Background(style.GetBackground())
+}
+
+// renderMatchHighlights styles the runes of the displayed text that were
+// matched in the original text.  The displayed text may have been truncated or
+// wrapped, so it's lined up with the original text as it goes, treating any
+// runes that aren't in the original (such as a truncation tail) as unmatched.
+// Each part is styled with the cell's text style so that the rest of the cell
+// keeps its style after the highlighted parts.
+func renderMatchHighlights(original, displayed string, matched []bool, cellTextStyle, matchStyle lipgloss.Style) string {
+	originalRunes := []rune(original)
+	originalIndex := 0
+
+	highlightStyle := matchStyle.Copy().Inherit(cellTextStyle).Inline(true)
+	normalStyle := cellTextStyle.Copy().Inline(true)
+
+	lines := strings.Split(displayed, "\n")
+
+	for lineIndex, line := range lines {
+		var (
+			result      strings.Builder
+			segment     strings.Builder
+			isHighlight bool
+		)
+
+		flush := func() {
+			if segment.Len() == 0 {
+				return
+			}
+
+			if isHighlight {
+				result.WriteString(highlightStyle.Render(segment.String()))
+			} else {
+				result.WriteString(normalStyle.Render(segment.String()))
+			}
+
+			segment.Reset()
+		}
+
+		for _, r := range line {
+			runeMatched := false
+
+			// Wrapping may drop spaces, so skip over them to find this rune
+			next := originalIndex
+			for next < len(originalRunes) && originalRunes[next] != r && unicode.IsSpace(originalRunes[next]) {
+				next++
+			}
+
+			if next < len(originalRunes) && originalRunes[next] == r {
+				runeMatched = next < len(matched) && matched[next]
+				originalIndex = next + 1
+			}
+
+			if runeMatched != isHighlight {
+				flush()
+				isHighlight = runeMatched
+			}
+
+			segment.WriteRune(r)
+		}
+
+		flush()
+
+		lines[lineIndex] = result.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
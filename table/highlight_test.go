@@ -0,0 +1,185 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+var testMatchStyle = lipgloss.NewStyle().Underline(true)
+
+func highlightTestModel(filter string) Model {
+	return New([]Column{
+		NewColumn("name", "Name", 20).WithFiltered(true),
+		NewColumn("type", "Type", 20).WithFiltered(true),
+		NewColumn("hp", "HP", 5).WithFiltered(true),
+		NewColumn("notes", "Notes", 20),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "Charmander", "type": "Fire", "hp": 39, "notes": "fire"}),
+	}).Filtered(true).
+		WithFilterMatchHighlighting(true).
+		WithFilterMatchStyle(testMatchStyle).
+		WithFilterInputValue(filter)
+}
+
+func renderTestCell(model Model, columnIndex int, rowStyle lipgloss.Style) string {
+	row := model.GetVisibleRows()[0]
+
+	return model.renderRowColumnData(row, model.columns[columnIndex], rowStyle, lipgloss.NewStyle(), false)
+}
+
+func TestSubstringMatchedRunes(t *testing.T) {
+	columns := []Column{
+		NewColumn("name", "Name", 10).WithFiltered(true),
+		NewColumn("hp", "HP", 5).WithFiltered(true),
+	}
+
+	tests := []struct {
+		name     string
+		filter   string
+		column   int
+		data     interface{}
+		text     string
+		expected []bool
+	}{
+		{
+			name:     "Every occurrence ignoring case",
+			filter:   "ab",
+			column:   0,
+			data:     "AbcaB",
+			text:     "AbcaB",
+			expected: []bool{true, true, false, true, true},
+		},
+		{
+			name:     "Multiple terms",
+			filter:   "a c",
+			column:   0,
+			data:     "abc",
+			text:     "abc",
+			expected: []bool{true, false, true},
+		},
+		{
+			name:     "Negated term",
+			filter:   "-a",
+			column:   0,
+			data:     "abc",
+			text:     "abc",
+			expected: []bool{false, false, false},
+		},
+		{
+			name:     "Other column",
+			filter:   "hp:a",
+			column:   0,
+			data:     "abc",
+			text:     "abc",
+			expected: []bool{false, false, false},
+		},
+		{
+			name:     "Numeric comparison",
+			filter:   "hp>30",
+			column:   1,
+			data:     39,
+			text:     "39",
+			expected: []bool{true, true},
+		},
+		{
+			name:     "Failed numeric comparison",
+			filter:   "hp>50",
+			column:   1,
+			data:     39,
+			text:     "39",
+			expected: []bool{false, false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			terms, err := parseFilter(columns, test.filter)
+
+			assert.NoError(t, err)
+
+			matched := substringMatchedRunes(columns[test.column], test.data, test.text, terms)
+
+			assert.Equal(t, test.expected, matched)
+		})
+	}
+}
+
+func TestHighlightDisabledByDefault(t *testing.T) {
+	model := highlightTestModel("char").WithFilterMatchHighlighting(false)
+
+	assert.Nil(t, model.filterMatchedRunes(model.columns[0], "Charmander", "Charmander"))
+}
+
+func TestHighlightSubstring(t *testing.T) {
+	model := highlightTestModel("char")
+
+	rendered := renderTestCell(model, 0, lipgloss.NewStyle())
+
+	assert.Equal(t, "          "+testMatchStyle.Render("Char")+"mander", rendered)
+}
+
+func TestHighlightSkipsUnfilterableColumns(t *testing.T) {
+	model := highlightTestModel("fire")
+
+	assert.Contains(t, renderTestCell(model, 1, lipgloss.NewStyle()), testMatchStyle.Render("Fire"))
+	assert.Equal(t, "                fire", renderTestCell(model, 3, lipgloss.NewStyle()))
+}
+
+func TestHighlightRegex(t *testing.T) {
+	model := highlightTestModel("a.").WithFilterMode(FilterModeRegex)
+
+	rendered := renderTestCell(model, 0, lipgloss.NewStyle())
+
+	expected := "          Ch" + testMatchStyle.Render("ar") + "m" + testMatchStyle.Render("an") + "der"
+
+	assert.Equal(t, expected, rendered)
+}
+
+func TestHighlightKeepsRowAndCellStyles(t *testing.T) {
+	rowStyle := lipgloss.NewStyle().Bold(true)
+	cellStyle := lipgloss.NewStyle().Italic(true)
+
+	model := highlightTestModel("char").WithRows([]Row{
+		NewRow(RowData{"name": NewStyledCell("Charmander", cellStyle)}),
+	})
+
+	rendered := renderTestCell(model, 0, rowStyle)
+
+	textStyle := lipgloss.NewStyle().Bold(true).Italic(true)
+
+	assert.Contains(t, rendered, testMatchStyle.Copy().Inherit(textStyle).Render("Char"))
+	assert.Contains(t, rendered, textStyle.Render("mander"))
+}
+
+func TestHighlightMultiline(t *testing.T) {
+	model := New([]Column{
+		NewColumn("name", "Name", 6).WithFiltered(true),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "fire and fires"}),
+	}).WithMultiline(true).
+		Filtered(true).
+		WithFilterMatchHighlighting(true).
+		WithFilterMatchStyle(testMatchStyle).
+		WithFilterInputValue("fire")
+
+	rendered := renderTestCell(model, 0, lipgloss.NewStyle())
+
+	lines := strings.Split(rendered, "\n")
+
+	assert.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], testMatchStyle.Render("fire")))
+	assert.True(t, strings.HasPrefix(lines[1], "and"))
+	assert.True(t, strings.HasPrefix(lines[2], testMatchStyle.Render("fire")+"s"))
+}
+
+func TestHighlightSkipsMissingDataIndicator(t *testing.T) {
+	model := highlightTestModel("char").
+		WithMissingDataIndicator("char").
+		WithRows([]Row{NewRow(RowData{"name": "Charmander"})})
+
+	assert.Contains(t, renderTestCell(model, 0, lipgloss.NewStyle()), testMatchStyle.Render("Char"))
+	assert.Equal(t, "                char", renderTestCell(model, 1, lipgloss.NewStyle()))
+}
@@ -7,8 +7,30 @@ type KeyMap struct {
 	RowDown key.Binding
 	RowUp   key.Binding
 
+	// ColumnLeft and ColumnRight move the highlighted cell between columns.
+	// Only used when the cell cursor is enabled with WithCellCursor.
+	ColumnLeft  key.Binding
+	ColumnRight key.Binding
+
 	RowSelectToggle key.Binding
 
+	// RowSelectRangeDown and RowSelectRangeUp move the highlighted row and
+	// select every row between it and the last toggled row.
+	RowSelectRangeDown key.Binding
+	RowSelectRangeUp   key.Binding
+
+	// RowSelectAll selects all rows that are currently visible.
+	RowSelectAll key.Binding
+
+	// RowSelectNone deselects all rows.
+	RowSelectNone key.Binding
+
+	// RowSelectInvert flips the selection of all rows that are currently visible.
+	RowSelectInvert key.Binding
+
+	// RowSelectPage selects all rows on the current page.
+	RowSelectPage key.Binding
+
 	PageDown  key.Binding
 	PageUp    key.Binding
 	PageFirst key.Binding
@@ -23,11 +45,56 @@ type KeyMap struct {
 	// FilterClear will clear the filter while it's blurred.
 	FilterClear key.Binding
 
+	// ColumnFilterNext and ColumnFilterPrevious move between the filter inputs
+	// under the header while typing into one.  Only used when the column
+	// filter row is enabled with WithColumnFilterRow.
+	ColumnFilterNext     key.Binding
+	ColumnFilterPrevious key.Binding
+
+	// FacetPickerOpen opens the facet picker for the highlighted column, which
+	// lists its distinct values to filter by.  Only used when the cell cursor
+	// is enabled with WithCellCursor.  While open, RowUp and RowDown move
+	// between values, FacetPickerToggle checks or unchecks a value, and
+	// FacetPickerClose closes the picker.
+	FacetPickerOpen   key.Binding
+	FacetPickerToggle key.Binding
+	FacetPickerClose  key.Binding
+
+	// FilterModeToggle cycles through the filter modes, such as substring,
+	// fuzzy, and regex.  Works while typing into the filter as well.
+	FilterModeToggle key.Binding
+
 	// ScrollRight will move one column to the right when overflow occurs.
 	ScrollRight key.Binding
 
 	// ScrollLeft will move one column to the left when overflow occurs.
 	ScrollLeft key.Binding
+
+	// CellEdit opens an editor for the highlighted cell, if its column is
+	// editable.  Only used when the cell cursor is enabled with WithCellCursor.
+	CellEdit key.Binding
+
+	// CellEditCommit saves the value in the cell editor.
+	CellEditCommit key.Binding
+
+	// CellEditCancel closes the cell editor without saving.
+	CellEditCancel key.Binding
+
+	// SortColumnNext and SortColumnPrevious pick which column the sort keys
+	// apply to by moving the highlighted column, skipping any columns that
+	// are not sortable.  The sort keys are only used when the cell cursor is
+	// enabled with WithCellCursor, which shows the highlighted column.
+	SortColumnNext     key.Binding
+	SortColumnPrevious key.Binding
+
+	// SortToggle cycles the highlighted column through ascending, descending,
+	// and unsorted, replacing any other sorting.
+	SortToggle key.Binding
+
+	// SortToggleMulti cycles the highlighted column through ascending,
+	// descending, and unsorted, but keeps any other sorted columns.  A newly
+	// sorted column is used to break ties of the existing sort.
+	SortToggleMulti key.Binding
 }
 
 // DefaultKeyMap returns a set of sensible defaults for controlling a focused table.
@@ -39,9 +106,33 @@ func DefaultKeyMap() KeyMap {
 		RowUp: key.NewBinding(
 			key.WithKeys("up", "k"),
 		),
+		ColumnLeft: key.NewBinding(
+			key.WithKeys("ctrl+left"),
+		),
+		ColumnRight: key.NewBinding(
+			key.WithKeys("ctrl+right"),
+		),
 		RowSelectToggle: key.NewBinding(
 			key.WithKeys(" ", "enter"),
 		),
+		RowSelectRangeDown: key.NewBinding(
+			key.WithKeys("shift+down"),
+		),
+		RowSelectRangeUp: key.NewBinding(
+			key.WithKeys("shift+up"),
+		),
+		RowSelectAll: key.NewBinding(
+			key.WithKeys("ctrl+a"),
+		),
+		RowSelectNone: key.NewBinding(
+			key.WithKeys("ctrl+n"),
+		),
+		RowSelectInvert: key.NewBinding(
+			key.WithKeys("*"),
+		),
+		RowSelectPage: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+		),
 		PageDown: key.NewBinding(
 			key.WithKeys("right", "l", "pgdown"),
 		),
@@ -63,11 +154,50 @@ func DefaultKeyMap() KeyMap {
 		FilterClear: key.NewBinding(
 			key.WithKeys("esc"),
 		),
+		ColumnFilterNext: key.NewBinding(
+			key.WithKeys("tab"),
+		),
+		ColumnFilterPrevious: key.NewBinding(
+			key.WithKeys("shift+tab"),
+		),
+		FacetPickerOpen: key.NewBinding(
+			key.WithKeys("f"),
+		),
+		FacetPickerToggle: key.NewBinding(
+			key.WithKeys(" ", "enter"),
+		),
+		FacetPickerClose: key.NewBinding(
+			key.WithKeys("esc", "f"),
+		),
+		FilterModeToggle: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+		),
 		ScrollRight: key.NewBinding(
 			key.WithKeys("shift+right"),
 		),
 		ScrollLeft: key.NewBinding(
 			key.WithKeys("shift+left"),
 		),
+		CellEdit: key.NewBinding(
+			key.WithKeys("e"),
+		),
+		CellEditCommit: key.NewBinding(
+			key.WithKeys("enter"),
+		),
+		CellEditCancel: key.NewBinding(
+			key.WithKeys("esc"),
+		),
+		SortColumnNext: key.NewBinding(
+			key.WithKeys(">"),
+		),
+		SortColumnPrevious: key.NewBinding(
+			key.WithKeys("<"),
+		),
+		SortToggle: key.NewBinding(
+			key.WithKeys("s"),
+		),
+		SortToggleMulti: key.NewBinding(
+			key.WithKeys("S"),
+		),
 	}
 }
@@ -1,6 +1,11 @@
 package table
 
 import (
+	"context"
+	"regexp"
+	"sync/atomic"
+	"time"
+
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -11,7 +16,9 @@ const (
 )
 
 var (
-	defaultHighlightStyle = lipgloss.NewStyle().Background(lipgloss.Color("#334"))
+	defaultHighlightStyle     = lipgloss.NewStyle().Background(lipgloss.Color("#334"))
+	defaultHighlightCellStyle = lipgloss.NewStyle().Background(lipgloss.Color("#558"))
+	defaultFilterMatchStyle   = lipgloss.NewStyle().Bold(true).Underline(true)
 )
 
 // Model is the main table model.  Create using New().
@@ -21,8 +28,39 @@ type Model struct {
 	rows    []Row
 
 	// Caches for optimizations
-	visibleRowCacheUpdated bool
-	visibleRowCache        []Row
+	visibleRowCacheUpdated     bool
+	visibleRowCache            []Row
+	visibleRowSourceIndexCache []int
+
+	// What changed since the visible rows were cached, and what they were
+	// built from, so that the cache can be updated instead of rebuilt
+	visibleRowChanges                 visibleRowChanges
+	visibleRowCacheSourceCount        int
+	visibleRowCacheFilterText         string
+	visibleRowCacheColumnFilterValues map[string]string
+
+	// Incremented whenever the visible rows become out of date
+	visibleRowsVersion int
+
+	// Tells tables apart when messages from their commands arrive
+	tableID int
+
+	// If asyncFilterSort is set, rows are filtered and sorted in the background
+	// while the cached rows keep being shown.  The version is the visible rows
+	// version that the rows being built will be up to date with.
+	asyncFilterSort bool
+	asyncVersion    int
+	asyncPending    bool
+	asyncCancel     context.CancelFunc
+
+	// Set while Update handles a message, so that any filtering and sorting it
+	// causes is left for the background
+	asyncInUpdate bool
+
+	// Used instead of rows if set, with the view being the filtered and sorted
+	// rows if the data source can provide them
+	dataSource     DataSource
+	dataSourceView DataSource
 
 	// Shown when data is missing from a row
 	missingDataIndicator interface{}
@@ -33,16 +71,46 @@ type Model struct {
 	selectableRows bool
 	rowCursorIndex int
 
+	// The row that range selection starts from, or -1 if none has been set
+	selectionAnchorIndex int
+
+	// The row that the last range selection from the anchor ended at, or -1
+	// if there hasn't been one since the anchor was set
+	selectionRangeEndIndex int
+
+	// If followMode is enabled, the highlight stays on the newest row while
+	// following is true
+	followMode bool
+	following  bool
+
+	// Limits how many rows are kept by AppendRows, 0 if unlimited
+	maxRows int
+
+	// If true, a single cell in the highlighted row is also highlighted and
+	// can be moved between columns
+	cellCursor        bool
+	columnCursorIndex int
+
 	// Events
 	lastUpdateUserEvents []UserEvent
 
 	// Styles
-	baseStyle      lipgloss.Style
-	highlightStyle lipgloss.Style
-	headerStyle    lipgloss.Style
-	border         Border
-	selectedText   string
-	unselectedText string
+	baseStyle          lipgloss.Style
+	highlightStyle     lipgloss.Style
+	highlightCellStyle lipgloss.Style
+	filterMatchStyle   lipgloss.Style
+
+	// Whether to highlight matches of substring and regex filters, since
+	// fuzzy filters are always highlighted
+	filterMatchHighlighting bool
+	headerStyle             lipgloss.Style
+	border                  Border
+	selectedText            string
+	unselectedText          string
+
+	// Shown in the header of sorted columns, or not at all if empty
+	sortIndicatorAsc  string
+	sortIndicatorDesc string
 
 	// Header
 	headerVisible bool
@@ -60,9 +128,46 @@ type Model struct {
 	// that elements are grouped by the later elements.
 	sortOrder []SortColumn
 
+	// How values are compared when sorting
+	sortOptions sortOptions
+
 	// Filter
-	filtered        bool
-	filterTextInput textinput.Model
+	filtered         bool
+	filterTextInput  textinput.Model
+	filterMode       FilterMode
+	fuzzySortByScore bool
+	filterFunc       FilterFunc
+
+	// The last valid pattern and any error with the current filter text when
+	// using FilterModeRegex
+	filterRegex      *regexp.Regexp
+	filterRegexError error
+
+	// While the user is typing with a debounce set, the filter that is still
+	// applied to the rows, and the tag of the tick that will apply the input
+	filterDebounce        time.Duration
+	filterDebouncePending bool
+	filterDebounceTag     int
+	appliedFilterText     string
+
+	// Per-column filters in a row under the header.  The input is only used
+	// for the focused column, and the index is -1 when none is focused.
+	columnFilterRow        bool
+	columnFilterValues     map[string]string
+	columnFilterInput      textinput.Model
+	columnFilterFocusIndex int
+
+	// Values checked in the facet picker, keyed by column key, and which
+	// column the picker is open for, or empty if closed.  The values listed in
+	// the picker are read from the rows when it opens or the rows change.
+	facetFilters         map[string][]string
+	facetPickerColumnKey string
+	facetPickerCursor    int
+	facetPickerValues    []facetValue
+
+	// Editing cells
+	cellEditTextInput textinput.Model
+	cellEditError     error
 
 	// For flex columns
 	targetTotalWidth int
@@ -86,6 +191,21 @@ type Model struct {
 	// Minimum total height of the table
 	minimumHeight int
 
+	// Maximum total height of the table, which enables vertical scrolling if
+	// pagination is not being used
+	maxHeight int
+
+	// How far down the table has been scrolled vertically, in rows
+	verticalScrollOffsetRow int
+
+	// How many rows to try and keep visible above and below the cursor when
+	// scrolling vertically
+	verticalScrollMargin int
+
+	// If true, shows a scrollbar to the right of the table when scrolling
+	// vertically
+	scrollbarVisible bool
+
 	// Internal cached calculation, the height of the header and footer
 	// including borders. Used to determine how many padding rows to add.
 	metaHeight int
@@ -94,25 +214,48 @@ type Model struct {
 	multiline bool
 }
 
+var lastTableID int64
+
+func nextTableID() int {
+	return int(atomic.AddInt64(&lastTableID, 1))
+}
+
 // New creates a new table ready for further modifications.
 func New(columns []Column) Model {
 	filterInput := textinput.New()
 	filterInput.Prompt = "/"
+	columnFilterInput := textinput.New()
+	columnFilterInput.Prompt = ""
 	model := Model{
-		columns:        make([]Column, len(columns)),
-		highlightStyle: defaultHighlightStyle.Copy(),
-		border:         borderDefault,
-		headerVisible:  true,
-		footerVisible:  true,
-		keyMap:         DefaultKeyMap(),
+		columns:            make([]Column, len(columns)),
+		highlightStyle:     defaultHighlightStyle.Copy(),
+		highlightCellStyle: defaultHighlightCellStyle.Copy(),
+		filterMatchStyle:   defaultFilterMatchStyle.Copy(),
+		border:             borderDefault,
+		headerVisible:      true,
+		footerVisible:      true,
+		keyMap:             DefaultKeyMap(),
+
+		selectionAnchorIndex:   -1,
+		selectionRangeEndIndex: -1,
 
 		selectedText:   "[x]",
 		unselectedText: "[ ]",
 
-		filterTextInput: filterInput,
-		baseStyle:       lipgloss.NewStyle().Align(lipgloss.Right),
+		sortIndicatorAsc:  "▲",
+		sortIndicatorDesc: "▼",
+
+		filterTextInput:   filterInput,
+		cellEditTextInput: textinput.New(),
+
+		columnFilterInput:      columnFilterInput,
+		columnFilterFocusIndex: -1,
+
+		baseStyle: lipgloss.NewStyle().Align(lipgloss.Right),
 
 		paginationWrapping: true,
+
+		tableID: nextTableID(),
 	}
 
 	// Do a full deep copy to avoid unexpected edits
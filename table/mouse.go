@@ -0,0 +1,199 @@
+package table
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Mouse coordinates are always treated as relative to the top left corner of
+// the table, where (0, 0) is the top left border.  If the table is rendered
+// somewhere else on the screen, the parent model should translate the X and Y
+// of the tea.MouseMsg before passing it in to Update.
+
+func (m *Model) handleMouse(msg tea.MouseMsg) {
+	previousRowIndex := m.rowCursorIndex
+	previousColumnIndex := m.columnCursorIndex
+
+	switch msg.Type {
+	case tea.MouseWheelUp:
+		m.moveHighlightUpNoWrap()
+
+	case tea.MouseWheelDown:
+		m.moveHighlightDownNoWrap()
+
+	case tea.MouseLeft:
+		m.handleMouseClick(msg.X, msg.Y)
+
+	default:
+	}
+
+	m.appendHighlightChangedEvents(previousRowIndex, previousColumnIndex)
+}
+
+func (m *Model) moveHighlightUpNoWrap() {
+	if m.rowCursorIndex <= 0 {
+		return
+	}
+
+	m.rowCursorIndex--
+	m.currentPage = m.expectedPageForRowIndex(m.rowCursorIndex)
+}
+
+func (m *Model) moveHighlightDownNoWrap() {
+	if m.rowCursorIndex >= m.visibleRowCount()-1 {
+		return
+	}
+
+	m.rowCursorIndex++
+	m.currentPage = m.expectedPageForRowIndex(m.rowCursorIndex)
+}
+
+func (m *Model) handleMouseClick(x, y int) {
+	columnIndex, columnFound := m.columnIndexAtX(x)
+
+	if m.isColumnFilterLineAtY(y) {
+		if columnFound && m.columns[columnIndex].filterable {
+			if !m.columnFilterFocused() {
+				m.appendUserEvent(UserEventFilterInputFocused{})
+			}
+
+			m.focusColumnFilter(columnIndex)
+		}
+
+		return
+	}
+
+	if m.isHeaderLineAtY(y) {
+		if columnFound {
+			m.handleHeaderClick(m.columns[columnIndex])
+		}
+
+		return
+	}
+
+	rowIndex, rowFound := m.rowIndexAtY(y)
+
+	if !rowFound {
+		return
+	}
+
+	m.rowCursorIndex = rowIndex
+
+	columnKey := ""
+
+	if columnFound {
+		columnKey = m.columns[columnIndex].key
+
+		if m.cellCursor && columnKey != columnKeySelect {
+			m.columnCursorIndex = columnIndex
+		}
+	}
+
+	m.appendUserEvent(UserEventCellClicked{
+		RowIndex:  rowIndex,
+		ColumnKey: columnKey,
+	})
+
+	if columnKey == columnKeySelect {
+		m.toggleSelect()
+	}
+}
+
+func (m *Model) handleHeaderClick(column Column) {
+	if column.key == columnKeySelect {
+		return
+	}
+
+	m.appendUserEvent(UserEventHeaderClicked{
+		ColumnKey: column.key,
+	})
+
+	if !column.sortable {
+		return
+	}
+
+	direction := SortDirectionAsc
+
+	// The last sort column is the primary one, so that's what we flip
+	if len(m.sortOrder) > 0 {
+		primary := m.sortOrder[len(m.sortOrder)-1]
+
+		if primary.ColumnKey == column.key && primary.Direction == SortDirectionAsc {
+			direction = SortDirectionDesc
+		}
+	}
+
+	m.setSortOrderFromUser([]SortColumn{{ColumnKey: column.key, Direction: direction}})
+}
+
+// headerHeight returns the number of lines above the first row, including
+// the top border.
+func (m *Model) headerHeight() int {
+	if !m.headerVisible {
+		// Only the top border is rendered
+		return 1
+	}
+
+	return lipgloss.Height(m.renderHeaders())
+}
+
+func (m *Model) isHeaderLineAtY(y int) bool {
+	if !m.headerVisible {
+		return false
+	}
+
+	bottom := m.headerHeight() - 1
+
+	if m.isColumnFilterRowVisible() {
+		bottom--
+	}
+
+	// Ignore the top and bottom borders of the header
+	return y > 0 && y < bottom
+}
+
+// isColumnFilterLineAtY returns true if the given line is the column filter
+// row, which is the last line of the header before its bottom border.
+func (m *Model) isColumnFilterLineAtY(y int) bool {
+	if !m.isColumnFilterRowVisible() {
+		return false
+	}
+
+	return y == m.headerHeight()-2
+}
+
+// rowIndexAtY returns the index of the visible row that is rendered at the
+// given line, if any.
+func (m *Model) rowIndexAtY(y int) (int, bool) {
+	currentY := m.headerHeight()
+
+	if y < currentY {
+		return 0, false
+	}
+
+	startRowIndex, endRowIndex := m.VisibleIndices()
+
+	for i, row := range m.visibleRowsBetween(startRowIndex, endRowIndex) {
+		currentY += m.rowContentHeight(row, row.Style)
+
+		if y < currentY {
+			return startRowIndex + i, true
+		}
+	}
+
+	return 0, false
+}
+
+// columnIndexAtX returns the index of the column that is rendered at the given
+// horizontal position, if any.  Borders are considered to be part of the column
+// on their left, except for the leftmost border which belongs to the first
+// rendered column.
+func (m *Model) columnIndexAtX(x int) (int, bool) {
+	for _, rendered := range m.renderedColumnLayout() {
+		if x >= rendered.start && x < rendered.end {
+			return rendered.index, true
+		}
+	}
+
+	return 0, false
+}
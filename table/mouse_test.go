@@ -0,0 +1,252 @@
+package table
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func mouseTestModel() Model {
+	return New([]Column{
+		NewColumn("id", "ID", 3),
+		NewColumn("name", "Name", 8),
+	}).WithRows([]Row{
+		NewRow(RowData{"id": "c", "name": "Third"}),
+		NewRow(RowData{"id": "a", "name": "First"}),
+		NewRow(RowData{"id": "b", "name": "Second"}),
+	}).Focused(true)
+}
+
+func mouseClick(x, y int) tea.MouseMsg {
+	return tea.MouseMsg{
+		X:    x,
+		Y:    y,
+		Type: tea.MouseLeft,
+	}
+}
+
+func TestMouseClickRowHighlights(t *testing.T) {
+	model := mouseTestModel()
+
+	// Header is 3 lines tall, so the third row is at line 5
+	model, _ = model.Update(mouseClick(6, 5))
+
+	assert.Equal(t, 2, model.GetHighlightedRowIndex())
+
+	events := model.GetLastUpdateUserEvents()
+
+	assert.Contains(t, events, UserEventCellClicked{RowIndex: 2, ColumnKey: "name"})
+	assert.Contains(t, events, UserEventHighlightedIndexChanged{PreviousRowIndex: 0, SelectedRowIndex: 2})
+}
+
+func TestMouseClickOutsideRowsDoesNothing(t *testing.T) {
+	model := mouseTestModel()
+
+	for _, y := range []int{0, 2, 6, 20} {
+		model, _ = model.Update(mouseClick(2, y))
+
+		assert.Equal(t, 0, model.GetHighlightedRowIndex(), "Moved for y=%d", y)
+		assert.Empty(t, model.GetLastUpdateUserEvents(), "Unexpected events for y=%d", y)
+	}
+}
+
+func TestMouseUnfocusedDoesNothing(t *testing.T) {
+	model := mouseTestModel().Focused(false)
+
+	model, _ = model.Update(mouseClick(2, 4))
+
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+}
+
+func TestMouseClickColumnBoundaries(t *testing.T) {
+	model := mouseTestModel()
+
+	tests := []struct {
+		x           int
+		expectedKey string
+	}{
+		{0, "id"},
+		{3, "id"},
+		{4, "id"},
+		{5, "name"},
+		{13, "name"},
+		{14, ""},
+	}
+
+	for _, test := range tests {
+		model, _ = model.Update(mouseClick(test.x, 4))
+
+		assert.Equal(t, []UserEvent{
+			UserEventCellClicked{RowIndex: 1, ColumnKey: test.expectedKey},
+		}, model.GetLastUpdateUserEvents()[:1], "Wrong column for x=%d", test.x)
+	}
+}
+
+func TestMouseClickColumnWithScrolling(t *testing.T) {
+	model := New([]Column{
+		NewColumn("1", "1", 4),
+		NewColumn("2", "2", 4),
+		NewColumn("3", "3", 4),
+		NewColumn("4", "4", 4),
+	}).
+		WithRows([]Row{NewRow(RowData{})}).
+		WithMaxTotalWidth(18).
+		WithHorizontalFreezeColumnCount(1).
+		Focused(true)
+
+	model = model.ScrollRight()
+
+	// ┃1   ┃<┃3   ┃4   ┃
+	tests := []struct {
+		x           int
+		expectedKey string
+	}{
+		{1, "1"},
+		{6, ""},
+		{8, "3"},
+		{12, "3"},
+		{13, "4"},
+	}
+
+	for _, test := range tests {
+		model, _ = model.Update(mouseClick(test.x, 3))
+
+		events := model.GetLastUpdateUserEvents()
+
+		assert.Len(t, events, 1)
+		assert.Equal(t, UserEventCellClicked{RowIndex: 0, ColumnKey: test.expectedKey}, events[0], "Wrong column for x=%d", test.x)
+	}
+}
+
+func TestMouseClickWithPagination(t *testing.T) {
+	model := mouseTestModel().WithPageSize(2).PageDown()
+
+	// Only the third row is visible on the second page
+	model, _ = model.Update(mouseClick(2, 3))
+	assert.Equal(t, 2, model.GetHighlightedRowIndex())
+
+	model, _ = model.Update(mouseClick(2, 4))
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+}
+
+func TestMouseClickWithMultiline(t *testing.T) {
+	model := New([]Column{
+		NewColumn("name", "Name", 5),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "one two three"}),
+		NewRow(RowData{"name": "four"}),
+	}).WithMultiline(true).Focused(true)
+
+	// First row takes up lines 3, 4, and 5
+	model, _ = model.Update(mouseClick(2, 5))
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+
+	model, _ = model.Update(mouseClick(2, 6))
+	assert.Equal(t, 1, model.GetHighlightedRowIndex())
+}
+
+func TestMouseClickWithHiddenHeader(t *testing.T) {
+	model := mouseTestModel().WithHeaderVisibility(false)
+
+	model, _ = model.Update(mouseClick(2, 1))
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+
+	model, _ = model.Update(mouseClick(2, 2))
+	assert.Equal(t, 1, model.GetHighlightedRowIndex())
+}
+
+func TestMouseClickHeaderSorts(t *testing.T) {
+	model := mouseTestModel()
+
+	model, _ = model.Update(mouseClick(2, 1))
+
+	assert.Equal(t, []UserEvent{
+		UserEventHeaderClicked{ColumnKey: "id"},
+		UserEventSortChanged{SortColumns: []SortColumn{{ColumnKey: "id", Direction: SortDirectionAsc}}},
+	}, model.GetLastUpdateUserEvents())
+	assert.Equal(t, []SortColumn{{ColumnKey: "id", Direction: SortDirectionAsc}}, model.GetColumnSorting())
+	assert.Equal(t, "a", model.GetVisibleRows()[0].Data["id"])
+
+	model, _ = model.Update(mouseClick(2, 1))
+
+	assert.Equal(t, []SortColumn{{ColumnKey: "id", Direction: SortDirectionDesc}}, model.GetColumnSorting())
+	assert.Equal(t, "c", model.GetVisibleRows()[0].Data["id"])
+
+	model, _ = model.Update(mouseClick(2, 1))
+
+	assert.Equal(t, []SortColumn{{ColumnKey: "id", Direction: SortDirectionAsc}}, model.GetColumnSorting())
+
+	model, _ = model.Update(mouseClick(6, 1))
+
+	assert.Equal(t, []SortColumn{{ColumnKey: "name", Direction: SortDirectionAsc}}, model.GetColumnSorting())
+}
+
+func TestMouseClickSelectColumnToggles(t *testing.T) {
+	model := mouseTestModel().SelectableRows(true)
+
+	model, _ = model.Update(mouseClick(1, 4))
+
+	assert.Len(t, model.SelectedRows(), 1)
+	assert.Contains(t, model.GetLastUpdateUserEvents(), UserEventRowSelectToggled{RowIndex: 1, IsSelected: true})
+
+	// Clicking the select header should not sort
+	model, _ = model.Update(mouseClick(1, 1))
+
+	assert.Empty(t, model.GetColumnSorting())
+}
+
+func TestMouseWheelMovesWithoutWrapping(t *testing.T) {
+	model := mouseTestModel().WithPageSize(2)
+
+	wheelDown := tea.MouseMsg{Type: tea.MouseWheelDown}
+	wheelUp := tea.MouseMsg{Type: tea.MouseWheelUp}
+
+	model, _ = model.Update(wheelUp)
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+
+	model, _ = model.Update(wheelDown)
+	assert.Equal(t, 1, model.GetHighlightedRowIndex())
+	assert.Equal(t, []UserEvent{
+		UserEventHighlightedIndexChanged{PreviousRowIndex: 0, SelectedRowIndex: 1},
+	}, model.GetLastUpdateUserEvents())
+
+	model, _ = model.Update(wheelDown)
+	assert.Equal(t, 2, model.GetHighlightedRowIndex())
+	assert.Equal(t, 2, model.CurrentPage(), "Should have moved to the next page")
+
+	model, _ = model.Update(wheelDown)
+	assert.Equal(t, 2, model.GetHighlightedRowIndex())
+
+	model, _ = model.Update(wheelUp)
+	assert.Equal(t, 1, model.GetHighlightedRowIndex())
+	assert.Equal(t, 1, model.CurrentPage())
+}
+
+func TestMouseClickMovesCellCursor(t *testing.T) {
+	model := mouseTestModel().WithCellCursor(true)
+
+	model, _ = model.Update(mouseClick(6, 4))
+
+	assert.Equal(t, 1, model.GetHighlightedRowIndex())
+	assert.Equal(t, "name", model.GetHighlightedColumnKey())
+	assert.Contains(t, model.GetLastUpdateUserEvents(), UserEventHighlightedCellChanged{
+		PreviousRowIndex:  0,
+		PreviousColumnKey: "id",
+		RowIndex:          1,
+		ColumnKey:         "name",
+	})
+}
+
+func TestMouseClickUnsortableHeaderDoesNotSort(t *testing.T) {
+	model := mouseTestModel().WithColumns([]Column{
+		NewColumn("id", "ID", 3).WithSortable(false),
+		NewColumn("name", "Name", 8),
+	})
+
+	model, _ = model.Update(mouseClick(2, 1))
+
+	assert.Equal(t, []UserEvent{UserEventHeaderClicked{ColumnKey: "id"}}, model.GetLastUpdateUserEvents())
+	assert.Empty(t, model.GetColumnSorting())
+}
@@ -9,8 +9,8 @@ import (
 func (m Model) WithHighlightedRow(index int) Model {
 	m.rowCursorIndex = index
 
-	if m.rowCursorIndex >= len(m.GetVisibleRows()) {
-		m.rowCursorIndex = len(m.GetVisibleRows()) - 1
+	if m.rowCursorIndex >= m.visibleRowCount() {
+		m.rowCursorIndex = m.visibleRowCount() - 1
 	}
 
 	if m.rowCursorIndex < 0 {
@@ -18,6 +18,7 @@ func (m Model) WithHighlightedRow(index int) Model {
 	}
 
 	m.currentPage = m.expectedPageForRowIndex(m.rowCursorIndex)
+	m.updateVerticalScroll()
 
 	return m
 }
@@ -29,10 +30,26 @@ func (m Model) HeaderStyle(style lipgloss.Style) Model {
 	return m
 }
 
-// WithRows sets the rows to show as data in the table.
+// WithRows sets the rows to show as data in the table.  Any rows that were
+// already selected stay selected if the same row is given again, even if the
+// order has changed, unless the row is given with Row.Selected(false).  If rows have IDs set with Row.WithID, the highlighted
+// row and selections follow rows with the same ID even if the data is new.
+// Any data source set with WithDataSource is replaced.
 func (m Model) WithRows(rows []Row) Model {
-	m.rows = rows
-	m.visibleRowCacheUpdated = false
+	highlightedID := m.highlightedRowID()
+
+	m.invalidateVisibleRows()
+
+	return m.replaceRows(rows, highlightedID)
+}
+
+// replaceRows sets the rows after the visible rows have been marked as out of
+// date, keeping the highlight on the row with the given ID if there is one.
+func (m Model) replaceRows(rows []Row, highlightedID interface{}) Model {
+	m.dataSource = nil
+	m.dataSourceView = nil
+	m.rows = keepSelection(m.rows, rows)
+	m.refreshFacetPickerValues()
 
 	if m.rowCursorIndex >= len(m.rows) {
 		m.rowCursorIndex = len(m.rows) - 1
@@ -51,6 +68,14 @@ func (m Model) WithRows(rows []Row) Model {
 		}
 	}
 
+	m.highlightRowByID(highlightedID)
+
+	if m.following {
+		m.pinToNewestRow()
+	}
+
+	m.updateVerticalScroll()
+
 	return m
 }
 
@@ -76,13 +101,17 @@ func (m Model) SelectableRows(selectable bool) Model {
 	if hasSelectColumn != selectable {
 		if selectable {
 			m.columns = append([]Column{
-				NewColumn(columnKeySelect, m.selectedText, len([]rune(m.selectedText))),
+				NewColumn(columnKeySelect, m.selectedText, len([]rune(m.selectedText))).WithSortable(false),
 			}, m.columns...)
+			m.columnCursorIndex++
 		} else {
 			m.columns = m.columns[1:]
+			m.columnCursorIndex--
 		}
 	}
 
+	m.clampColumnCursor()
+
 	m.recalculateWidth()
 
 	return m
@@ -90,25 +119,27 @@ func (m Model) SelectableRows(selectable bool) Model {
 
 // HighlightedRow returns the full Row that's currently highlighted by the user.
 func (m Model) HighlightedRow() Row {
-	if len(m.GetVisibleRows()) > 0 {
-		return m.GetVisibleRows()[m.rowCursorIndex]
+	if m.visibleRowCount() > 0 {
+		return m.visibleRowAt(m.rowCursorIndex)
 	}
 
 	// TODO: Better way to do this without pointers/nil?  Or should it be nil?
 	return Row{}
 }
 
-// SelectedRows returns all rows that have been set as selected by the user.
+// SelectedRows returns all rows that have been set as selected by the user,
+// including any selected rows that are currently hidden by a filter.  The rows
+// are returned in the current sort order.
 func (m Model) SelectedRows() []Row {
 	selectedRows := []Row{}
 
-	for _, row := range m.GetVisibleRows() {
+	for _, row := range m.rows {
 		if row.selected {
 			selectedRows = append(selectedRows, row)
 		}
 	}
 
-	return selectedRows
+	return getSortedRows(m.columns, m.sortOrder, m.sortOptions, selectedRows)
 }
 
 // HighlightStyle sets a custom style to use when the row is being highlighted
@@ -119,6 +150,56 @@ func (m Model) HighlightStyle(style lipgloss.Style) Model {
 	return m
 }
 
+// HighlightCellStyle sets a custom style to use for the single cell that is
+// highlighted by the cell cursor.  This is applied on top of the row's
+// highlight style.  Only used when the cell cursor is enabled.
+func (m Model) HighlightCellStyle(style lipgloss.Style) Model {
+	m.highlightCellStyle = style
+
+	return m
+}
+
+// WithCellCursor sets whether a single cell within the highlighted row should
+// also be highlighted.  If enabled, the highlighted cell can be moved between
+// columns with the ColumnLeft and ColumnRight keys, and the table will scroll
+// horizontally to keep the highlighted cell visible.
+func (m Model) WithCellCursor(enabled bool) Model {
+	m.cellCursor = enabled
+
+	m.clampColumnCursor()
+
+	return m
+}
+
+// WithHighlightedColumn sets the highlighted cell to the column with the given
+// key.  If no such column exists, nothing is changed.  Only used when the cell
+// cursor is enabled.
+func (m Model) WithHighlightedColumn(columnKey string) Model {
+	for index, column := range m.columns {
+		if column.key == columnKey && column.key != columnKeySelect {
+			m.columnCursorIndex = index
+			m.scrollToHighlightedColumn()
+
+			break
+		}
+	}
+
+	return m
+}
+
+// HighlightedCell returns the data of the single cell that's currently
+// highlighted by the user, or nil if there is no data.  Only useful when the
+// cell cursor is enabled.
+func (m Model) HighlightedCell() interface{} {
+	columnKey := m.GetHighlightedColumnKey()
+
+	if columnKey == "" {
+		return nil
+	}
+
+	return m.HighlightedRow().Data[columnKey]
+}
+
 // Focused allows the table to show highlighted rows and take in controls of
 // up/down/space/etc to let the user navigate the table and interact with it.
 func (m Model) Focused(focused bool) Model {
@@ -130,9 +211,9 @@ func (m Model) Focused(focused bool) Model {
 // Filtered allows the table to show rows that match the filter.
 func (m Model) Filtered(filtered bool) Model {
 	m.filtered = filtered
-	m.visibleRowCacheUpdated = false
+	m.invalidateFilterAndSort()
 
-	if m.minimumHeight > 0 {
+	if m.hasHeightConstraint() {
 		m.recalculateHeight()
 	}
 
@@ -150,7 +231,7 @@ func (m Model) StartFilterTyping() Model {
 func (m Model) WithStaticFooter(footer string) Model {
 	m.staticFooter = footer
 
-	if m.minimumHeight > 0 {
+	if m.hasHeightConstraint() {
 		m.recalculateHeight()
 	}
 
@@ -162,13 +243,16 @@ func (m Model) WithStaticFooter(footer string) Model {
 func (m Model) WithPageSize(pageSize int) Model {
 	m.pageSize = pageSize
 
+	// The scrollbar is only shown without pagination
+	m.recalculateWidth()
+
 	maxPages := m.MaxPages()
 
 	if m.currentPage >= maxPages {
 		m.currentPage = maxPages - 1
 	}
 
-	if m.minimumHeight > 0 {
+	if m.hasHeightConstraint() {
 		m.recalculateHeight()
 	}
 
@@ -179,7 +263,9 @@ func (m Model) WithPageSize(pageSize int) Model {
 func (m Model) WithNoPagination() Model {
 	m.pageSize = 0
 
-	if m.minimumHeight > 0 {
+	m.recalculateWidth()
+
+	if m.hasHeightConstraint() {
 		m.recalculateHeight()
 	}
 
@@ -201,13 +287,24 @@ func (m Model) WithSelectedText(unselected, selected string) Model {
 	m.unselectedText = unselected
 
 	if len(m.columns) > 0 && m.columns[0].key == columnKeySelect {
-		m.columns[0] = NewColumn(columnKeySelect, m.selectedText, len([]rune(m.selectedText)))
+		m.columns[0] = NewColumn(columnKeySelect, m.selectedText, len([]rune(m.selectedText))).WithSortable(false)
 		m.recalculateWidth()
 	}
 
 	return m
 }
 
+// WithSortIndicators sets the indicators to show in the header of a column when
+// it's sorted in ascending or descending order.  Defaults to ▲ and ▼.  When
+// sorting by multiple columns, a priority number is also shown after the
+// indicator.  Set both to empty strings to hide sort indicators entirely.
+func (m Model) WithSortIndicators(ascending, descending string) Model {
+	m.sortIndicatorAsc = ascending
+	m.sortIndicatorDesc = descending
+
+	return m
+}
+
 // WithBaseStyle applies a base style as the default for everything in the table.
 // This is useful for border colors, default alignment, default color, etc.
 func (m Model) WithBaseStyle(style lipgloss.Style) Model {
@@ -236,6 +333,44 @@ func (m Model) WithMinimumHeight(minimumHeight int) Model {
 	return m
 }
 
+// WithMaxHeight sets the maximum total height of the table, including borders.
+// If the rows don't fit, the table will scroll vertically one row at a time to
+// follow the highlighted row rather than jumping a page at a time.  This is an
+// alternative to pagination and has no effect if a page size is set.  Set to
+// 0 to disable.
+func (m Model) WithMaxHeight(maxHeight int) Model {
+	m.maxHeight = maxHeight
+
+	m.recalculateWidth()
+	m.recalculateHeight()
+	m.updateVerticalScroll()
+
+	return m
+}
+
+// WithScrollMargin sets how many rows to try and keep visible above and below
+// the highlighted row when scrolling vertically with WithMaxHeight.  Defaults
+// to 0, which only scrolls once the highlighted row reaches the edge.
+func (m Model) WithScrollMargin(margin int) Model {
+	m.verticalScrollMargin = max(margin, 0)
+
+	m.updateVerticalScroll()
+
+	return m
+}
+
+// WithScrollbar sets whether to show a scrollbar to the right of the table when
+// scrolling vertically with WithMaxHeight.  The scrollbar uses the right border
+// color of the base style.  The scrollbar's column counts towards any width
+// set with WithTargetWidth or WithMaxTotalWidth.
+func (m Model) WithScrollbar(visible bool) Model {
+	m.scrollbarVisible = visible
+
+	m.recalculateWidth()
+
+	return m
+}
+
 // PageDown goes to the next page of a paginated table, wrapping to the first
 // page if the table is already on the last page.
 func (m Model) PageDown() Model {
@@ -300,10 +435,14 @@ func (m Model) WithColumns(columns []Column) Model {
 	m.recalculateWidth()
 
 	if m.selectableRows {
-		// Re-add the selectable column
+		// Re-add the selectable column, keeping the cell cursor on the same
+		// data column
+		m.columnCursorIndex--
 		m = m.SelectableRows(true)
 	}
 
+	m.clampColumnCursor()
+
 	return m
 }
 
@@ -311,12 +450,14 @@ func (m Model) WithColumns(columns []Column) Model {
 // filtering rather than using the built-in default.  This allows for external
 // text input controls to be used.
 func (m Model) WithFilterInput(input textinput.Model) Model {
-	if m.filterTextInput.Value() != input.Value() {
+	if m.filterText() != input.Value() {
 		m.pageFirst()
 	}
 
 	m.filterTextInput = input
-	m.visibleRowCacheUpdated = false
+	m.cancelFilterDebounce()
+	m.updateFilterRegex()
+	m.invalidateFilteredRows()
 
 	return m
 }
@@ -325,13 +466,77 @@ func (m Model) WithFilterInput(input textinput.Model) Model {
 // applying it as if the user had typed it in.  Useful for external filter inputs
 // that are not necessarily a text input.
 func (m Model) WithFilterInputValue(value string) Model {
-	if m.filterTextInput.Value() != value {
+	if m.filterText() != value {
 		m.pageFirst()
 	}
 
 	m.filterTextInput.SetValue(value)
 	m.filterTextInput.Blur()
-	m.visibleRowCacheUpdated = false
+	m.cancelFilterDebounce()
+	m.updateFilterRegex()
+	m.invalidateFilteredRows()
+
+	return m
+}
+
+// WithFilterFunc replaces the built-in matching of rows against the filter text
+// with the given function, which is called for each row while a filter is
+// entered.  The table still handles the filter input, footer, and pagination.
+// Because the function can check anything about a row, matches are not
+// highlighted and a QueryableDataSource is not asked to filter.  Set to nil to
+// use the built-in matching again.
+func (m Model) WithFilterFunc(filterFunc FilterFunc) Model {
+	if m.GetIsFilterActive() {
+		m.pageFirst()
+	}
+
+	m.filterFunc = filterFunc
+	m.invalidateFilterAndSort()
+
+	return m
+}
+
+// WithFilterMode sets how the filter text is matched against rows.  Defaults
+// to FilterModeSubstring.
+func (m Model) WithFilterMode(mode FilterMode) Model {
+	if m.filterMode != mode {
+		m.pageFirst()
+	}
+
+	m.filterMode = mode
+	m.updateFilterRegex()
+	m.invalidateFilterAndSort()
+
+	return m
+}
+
+// WithFuzzySortByScore sets whether rows matched by a fuzzy filter are ordered
+// by how well they match, best first, instead of by the table's sort order.
+// Rows that match equally well keep the table's sort order.  Only applies
+// while using FilterModeFuzzy.
+func (m Model) WithFuzzySortByScore(sortByScore bool) Model {
+	m.fuzzySortByScore = sortByScore
+	m.invalidateFilterAndSort()
+
+	return m
+}
+
+// WithFilterMatchHighlighting sets whether the parts of each cell that match
+// a substring or regex filter are highlighted with the filter match style, to
+// show why each row matched.  Only filterable columns are highlighted, and
+// terms limited to another column or negated terms are not highlighted.
+// Fuzzy filters are always highlighted.
+func (m Model) WithFilterMatchHighlighting(enabled bool) Model {
+	m.filterMatchHighlighting = enabled
+
+	return m
+}
+
+// WithFilterMatchStyle sets the style applied to the characters in each cell
+// that matched the filter.  The cell's own style still applies to anything the
+// match style does not set.  Defaults to bold and underlined.
+func (m Model) WithFilterMatchStyle(style lipgloss.Style) Model {
+	m.filterMatchStyle = style
 
 	return m
 }
@@ -340,7 +545,7 @@ func (m Model) WithFilterInputValue(value string) Model {
 func (m Model) WithFooterVisibility(visibility bool) Model {
 	m.footerVisible = visibility
 
-	if m.minimumHeight > 0 {
+	if m.hasHeightConstraint() {
 		m.recalculateHeight()
 	}
 
@@ -351,7 +556,7 @@ func (m Model) WithFooterVisibility(visibility bool) Model {
 func (m Model) WithHeaderVisibility(visibility bool) Model {
 	m.headerVisible = visibility
 
-	if m.minimumHeight > 0 {
+	if m.hasHeightConstraint() {
 		m.recalculateHeight()
 	}
 
@@ -415,17 +620,10 @@ func (m Model) WithMissingDataIndicatorStyled(styled StyledCell) Model {
 	return m
 }
 
-// WithAllRowsDeselected deselects any rows that are currently selected.
+// WithAllRowsDeselected deselects any rows that are currently selected,
+// including rows that are hidden by a filter.
 func (m Model) WithAllRowsDeselected() Model {
-	rows := m.GetVisibleRows()
-
-	for i, row := range rows {
-		if row.selected {
-			rows[i] = row.Selected(false)
-		}
-	}
-
-	m.rows = rows
+	m.deselectAllRows()
 
 	return m
 }
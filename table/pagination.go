@@ -14,7 +14,7 @@ func (m *Model) CurrentPage() int {
 
 // MaxPages returns the maximum number of pages that are visible.
 func (m *Model) MaxPages() int {
-	totalRows := len(m.GetVisibleRows())
+	totalRows := m.visibleRowCount()
 
 	if m.pageSize == 0 || totalRows == 0 {
 		return 1
@@ -26,13 +26,21 @@ func (m *Model) MaxPages() int {
 // TotalRows returns the current total row count of the table.  If the table is
 // paginated, this is the total number of rows across all pages.
 func (m *Model) TotalRows() int {
-	return len(m.GetVisibleRows())
+	return m.visibleRowCount()
 }
 
 // VisibleIndices returns the current visible rows by their 0 based index.
-// Useful for custom pagination footers.
+// Useful for custom pagination footers.  If the table is scrolling vertically
+// with WithMaxHeight, this is the current window of rows that fit.
 func (m *Model) VisibleIndices() (start, end int) {
-	totalRows := len(m.GetVisibleRows())
+	totalRows := m.visibleRowCount()
+
+	if m.isViewportScrolling() && totalRows > 0 {
+		start = m.viewportStartIndex(m.verticalScrollOffsetRow)
+		end = m.viewportEndIndex(start)
+
+		return start, end
+	}
 
 	if m.pageSize == 0 {
 		start = 0
@@ -52,7 +60,13 @@ func (m *Model) VisibleIndices() (start, end int) {
 }
 
 func (m *Model) pageDown() {
-	if m.pageSize == 0 || len(m.GetVisibleRows()) <= m.pageSize {
+	if m.isViewportScrolling() {
+		m.viewportPageDown()
+
+		return
+	}
+
+	if m.pageSize == 0 || m.visibleRowCount() <= m.pageSize {
 		return
 	}
 
@@ -72,7 +86,13 @@ func (m *Model) pageDown() {
 }
 
 func (m *Model) pageUp() {
-	if m.pageSize == 0 || len(m.GetVisibleRows()) <= m.pageSize {
+	if m.isViewportScrolling() {
+		m.viewportPageUp()
+
+		return
+	}
+
+	if m.pageSize == 0 || m.visibleRowCount() <= m.pageSize {
 		return
 	}
 
@@ -97,6 +117,13 @@ func (m *Model) pageFirst() {
 }
 
 func (m *Model) pageLast() {
+	if m.isViewportScrolling() {
+		m.rowCursorIndex = max(m.visibleRowCount()-1, 0)
+		m.updateVerticalScroll()
+
+		return
+	}
+
 	m.currentPage = m.MaxPages() - 1
 	m.rowCursorIndex = m.currentPage * m.pageSize
 }
@@ -1,5 +1,7 @@
 package table
 
+import "context"
+
 // GetColumnSorting returns the current sorting rules for the table as a list of
 // SortColumns, which are applied from first to last.  This means that data will
 // be grouped by the later elements in the list.  The returned list is a copy
@@ -20,48 +22,114 @@ func (m *Model) GetCanFilter() bool {
 
 // GetIsFilterActive returns true if the table is currently being filtered.  This
 // does not say whether the table CAN be filtered, only whether or not a filter
-// is actually currently being applied.
+// is actually currently being applied, either in the footer, in the column
+// filter row, or with the facet picker.
 func (m *Model) GetIsFilterActive() bool {
-	return m.filterTextInput.Value() != ""
+	return m.filterText() != "" || m.hasColumnFilters() || m.hasFacetFilters()
+}
+
+// GetIsEditingCell returns true if the user is currently editing a cell.
+func (m *Model) GetIsEditingCell() bool {
+	return m.cellEditTextInput.Focused()
+}
+
+// GetIsFollowing returns true if follow mode is enabled and the table is
+// currently following the newest row.
+func (m *Model) GetIsFollowing() bool {
+	return m.following
 }
 
-// GetIsFilterInputFocused returns true if the table's built-in filter input is
-// currently focused.
+// GetIsFilterInputFocused returns true if the table's built-in filter input or
+// one of the inputs in the column filter row is currently focused.
 func (m *Model) GetIsFilterInputFocused() bool {
-	return m.filterTextInput.Focused()
+	return m.filterTextInput.Focused() || m.columnFilterFocused()
+}
+
+// GetFacetFilters returns the values checked in the facet picker, keyed by
+// column key.  Rows are only shown if they have one of the checked values for
+// every column in the map.
+func (m *Model) GetFacetFilters() map[string][]string {
+	filters := make(map[string][]string, len(m.facetFilters))
+
+	for columnKey, values := range m.facetFilters {
+		filters[columnKey] = append([]string{}, values...)
+	}
+
+	return filters
+}
+
+// GetIsFacetPickerOpen returns true if the facet picker is currently open.
+func (m *Model) GetIsFacetPickerOpen() bool {
+	return m.isFacetPickerOpen()
+}
+
+// GetColumnFilterValues returns the filters entered in the column filter row,
+// keyed by column key.  Columns without a filter are not included.
+func (m *Model) GetColumnFilterValues() map[string]string {
+	values := make(map[string]string, len(m.columnFilterValues))
+
+	for columnKey, value := range m.columnFilterValues {
+		values[columnKey] = value
+	}
+
+	return values
 }
 
 // GetCurrentFilter returns the current filter text being applied, or an empty
 // string if none is applied.
 func (m *Model) GetCurrentFilter() string {
-	return m.filterTextInput.Value()
+	return m.filterText()
 }
 
-// GetVisibleRows returns sorted and filtered rows.
+// GetFilterMode returns how the filter text is matched against rows.
+func (m *Model) GetFilterMode() FilterMode {
+	return m.filterMode
+}
+
+// GetVisibleRows returns sorted and filtered rows.  If the table uses a data
+// source, this fetches every visible row from it.  While rows are being
+// filtered and sorted in the background with WithAsyncFilterSort, this returns
+// the rows that are still being shown.
 func (m *Model) GetVisibleRows() []Row {
-	if m.visibleRowCacheUpdated {
-		return m.visibleRowCache
+	if view := m.lazyVisibleRows(); view != nil {
+		return view.Rows(0, view.RowCount())
 	}
 
-	rows := make([]Row, len(m.rows))
-	copy(rows, m.rows)
-	if m.filtered {
-		rows = m.getFilteredRows(rows)
+	if m.visibleRowCacheUpdated || m.isWaitingForVisibleRows() {
+		return m.visibleRowCache
 	}
-	rows = getSortedRows(m.sortOrder, rows)
 
-	m.visibleRowCache = rows
-	m.visibleRowCacheUpdated = true
+	rows, sourceIndices, _ := m.buildVisibleRows(context.Background())
+
+	m.setVisibleRowCache(rows, sourceIndices)
 
 	return rows
 }
 
+// visibleRowSourceIndex returns the index in the full list of rows of the row
+// at the given visible index.
+func (m *Model) visibleRowSourceIndex(visibleIndex int) int {
+	m.GetVisibleRows()
+
+	return m.visibleRowSourceIndexCache[visibleIndex]
+}
+
 // GetHighlightedRowIndex returns the index of the Row that's currently highlighted
 // by the user.
 func (m *Model) GetHighlightedRowIndex() int {
 	return m.rowCursorIndex
 }
 
+// GetHighlightedColumnKey returns the key of the column that the cell cursor
+// is currently on, or an empty string if the cell cursor is not enabled.
+func (m *Model) GetHighlightedColumnKey() string {
+	if !m.cellCursor {
+		return ""
+	}
+
+	return m.columnKeyAtIndex(m.columnCursorIndex)
+}
+
 // GetFocused returns whether or not the table is focused and is receiving inputs.
 func (m *Model) GetFocused() bool {
 	return m.focused
@@ -74,6 +142,17 @@ func (m *Model) GetHorizontalScrollColumnOffset() int {
 	return m.horizontalScrollOffsetCol
 }
 
+// GetVerticalScrollRowOffset returns how many rows down the table has been
+// scrolled when using WithMaxHeight.  0 means the table is all the way at the
+// top, which is the starting default.
+func (m *Model) GetVerticalScrollRowOffset() int {
+	if !m.isViewportScrolling() {
+		return 0
+	}
+
+	return m.viewportStartIndex(m.verticalScrollOffsetRow)
+}
+
 // GetHeaderVisibility returns true if the header has been set to visible (default)
 // or false if the header has been set to hidden.
 func (m *Model) GetHeaderVisibility() bool {
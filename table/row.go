@@ -21,6 +21,19 @@ type Row struct {
 	Data  RowData
 
 	selected bool
+
+	// Set by Selected, so that an explicit selection isn't overridden when the
+	// table's rows are replaced
+	selectedSet bool
+
+	// Optional, used to find the same row again after the rows have changed
+	id interface{}
+
+	// Only used temporarily while generating the visible rows, to track where
+	// each row came from in the model's full list of rows and how well it
+	// matched the filter
+	sourceIndex int
+	filterScore int
 }
 
 // NewRow creates a new row and copies the given row data.
@@ -45,11 +58,14 @@ func (r Row) WithStyle(style lipgloss.Style) Row {
 }
 
 //nolint:nestif,cyclop // This has many ifs, but they're short
-func (m Model) renderRowColumnData(row Row, column Column, rowStyle lipgloss.Style, borderStyle lipgloss.Style) string {
+func (m Model) renderRowColumnData(row Row, column Column, rowStyle lipgloss.Style, borderStyle lipgloss.Style, isEditing bool) string {
 	cellStyle := rowStyle.Copy().Inherit(column.style).Inherit(m.baseStyle)
 
 	var str string
 
+	// Which runes of str match the filter, if any should be highlighted
+	var filterMatches []bool
+
 	if column.key == columnKeySelect {
 		if row.selected {
 			str = m.selectedText
@@ -61,6 +77,9 @@ func (m Model) renderRowColumnData(row Row, column Column, rowStyle lipgloss.Sty
 		str = ">"
 	} else if column.key == columnKeyOverflowLeft {
 		str = "<"
+	} else if isEditing {
+		cellStyle = cellStyle.Align(lipgloss.Left)
+		str = m.cellEditTextInput.View()
 	} else {
 		fmtString := "%v"
 
@@ -85,8 +104,15 @@ func (m Model) renderRowColumnData(row Row, column Column, rowStyle lipgloss.Sty
 		default:
 			str = fmt.Sprintf(fmtString, entry)
 		}
+
+		// Don't highlight the missing data indicator
+		if _, exists := row.Data[column.key]; exists {
+			filterMatches = m.filterMatchedRunes(column, data, str)
+		}
 	}
 
+	original := str
+
 	if m.multiline {
 		str = wordwrap.String(str, column.width)
 		cellStyle = cellStyle.Align(lipgloss.Top)
@@ -94,34 +120,58 @@ func (m Model) renderRowColumnData(row Row, column Column, rowStyle lipgloss.Sty
 		str = limitStr(str, column.width)
 	}
 
+	if filterMatches != nil {
+		str = renderMatchHighlights(original, str, filterMatches, textStyle(cellStyle), m.filterMatchStyle)
+	}
+
 	cellStyle = cellStyle.Inherit(borderStyle)
 	cellStr := cellStyle.Render(str)
 
 	return cellStr
 }
 
-func (m Model) renderRow(rowIndex int, last bool) string {
-	row := m.GetVisibleRows()[rowIndex]
+// rowContentHeight returns how many lines the row's cells take up, not
+// including any borders.  This is always 1 unless multiline is enabled.
+func (m Model) rowContentHeight(row Row, rowStyle lipgloss.Style) int {
+	maxCellHeight := 1
+
+	if m.multiline {
+		for _, column := range m.columns {
+			cellStr := m.renderRowColumnData(row, column, rowStyle, lipgloss.NewStyle(), false)
+			maxCellHeight = max(maxCellHeight, lipgloss.Height(cellStr))
+		}
+	}
+
+	return maxCellHeight
+}
+
+func (m Model) renderRow(row Row, rowIndex int, last bool) string {
 	highlighted := rowIndex == m.rowCursorIndex
 
 	rowStyle := row.Style.Copy()
 
+	highlightedColumnIndex := -1
+
 	if m.focused && highlighted {
 		rowStyle = rowStyle.Inherit(m.highlightStyle)
+
+		if m.cellCursor || m.cellEditTextInput.Focused() {
+			highlightedColumnIndex = m.columnCursorIndex
+		}
 	}
 
-	return m.renderRowData(row, rowStyle, last)
+	return m.renderRowData(row, rowStyle, highlightedColumnIndex, last)
 }
 
 func (m Model) renderBlankRow(last bool) string {
-	return m.renderRowData(NewRow(nil), lipgloss.NewStyle(), last)
+	return m.renderRowData(NewRow(nil), lipgloss.NewStyle(), -1, last)
 }
 
 // This is long and could use some refactoring in the future, but not quite sure
 // how to pick it apart yet.
 //
 //nolint:funlen, cyclop, gocognit
-func (m Model) renderRowData(row Row, rowStyle lipgloss.Style, last bool) string {
+func (m Model) renderRowData(row Row, rowStyle lipgloss.Style, highlightedColumnIndex int, last bool) string {
 	numColumns := len(m.columns)
 
 	columnStrings := []string{}
@@ -129,13 +179,7 @@ func (m Model) renderRowData(row Row, rowStyle lipgloss.Style, last bool) string
 
 	stylesInner, stylesLast := m.styleRows()
 
-	maxCellHeight := 1
-	if m.multiline {
-		for _, column := range m.columns {
-			cellStr := m.renderRowColumnData(row, column, rowStyle, lipgloss.NewStyle())
-			maxCellHeight = max(maxCellHeight, lipgloss.Height(cellStr))
-		}
-	}
+	maxCellHeight := m.rowContentHeight(row, rowStyle)
 
 	for columnIndex, column := range m.columns {
 		var borderStyle lipgloss.Style
@@ -157,7 +201,7 @@ func (m Model) renderRowData(row Row, rowStyle lipgloss.Style, last bool) string
 				borderStyle = rowStyles.inner.Copy()
 			}
 
-			rendered := m.renderRowColumnData(row, genOverflowColumnLeft(1), rowStyle, borderStyle)
+			rendered := m.renderRowColumnData(row, genOverflowColumnLeft(1), rowStyle, borderStyle, false)
 
 			totalRenderedWidth += lipgloss.Width(rendered)
 
@@ -177,9 +221,17 @@ func (m Model) renderRowData(row Row, rowStyle lipgloss.Style, last bool) string
 			borderStyle = rowStyles.right
 		}
 
-		cellStr := m.renderRowColumnData(row, column, rowStyle, borderStyle)
+		cellRowStyle := rowStyle
 
-		if m.maxTotalWidth != 0 {
+		if columnIndex == highlightedColumnIndex {
+			cellRowStyle = m.highlightCellStyle.Copy().Inherit(rowStyle)
+		}
+
+		isEditing := columnIndex == highlightedColumnIndex && m.cellEditTextInput.Focused()
+
+		cellStr := m.renderRowColumnData(row, column, cellRowStyle, borderStyle, isEditing)
+
+		if m.maxTableWidth() != 0 {
 			renderedWidth := lipgloss.Width(cellStr)
 
 			const (
@@ -187,19 +239,19 @@ func (m Model) renderRowData(row Row, rowStyle lipgloss.Style, last bool) string
 				overflowColWidth = 2
 			)
 
-			targetWidth := m.maxTotalWidth - overflowColWidth
+			targetWidth := m.maxTableWidth() - overflowColWidth
 
 			if columnIndex == len(m.columns)-1 {
 				// If this is the last header, we don't need to account for the
 				// overflow arrow column
-				targetWidth = m.maxTotalWidth
+				targetWidth = m.maxTableWidth()
 			}
 
 			if totalRenderedWidth+renderedWidth > targetWidth {
-				overflowWidth := m.maxTotalWidth - totalRenderedWidth - borderAdjustment
+				overflowWidth := m.maxTableWidth() - totalRenderedWidth - borderAdjustment
 				overflowStyle := genOverflowStyle(rowStyles.right, overflowWidth)
 				overflowColumn := genOverflowColumnRight(overflowWidth)
-				overflowStr := m.renderRowColumnData(row, overflowColumn, rowStyle, overflowStyle)
+				overflowStr := m.renderRowColumnData(row, overflowColumn, rowStyle, overflowStyle, false)
 
 				columnStrings = append(columnStrings, overflowStr)
 
@@ -216,9 +268,28 @@ func (m Model) renderRowData(row Row, rowStyle lipgloss.Style, last bool) string
 }
 
 // Selected returns a copy of the row that's set to be selected or deselected.
-// The old row is not changed in-place.
+// The old row is not changed in-place.  When the table's rows are replaced, a
+// row's selection set this way is used instead of the selection of the row it
+// replaces.
 func (r Row) Selected(selected bool) Row {
 	r.selected = selected
+	r.selectedSet = true
 
 	return r
 }
+
+// WithID returns a copy of the row with the given ID.  The ID identifies the
+// row when the table's rows are replaced, so that the highlighted row and any
+// selection can follow the same logical row, and is used for keyed updates such
+// as UpdateRow.  The ID must be comparable, such as a string or an int, and
+// should be unique within the table.  The old row is not changed in-place.
+func (r Row) WithID(id interface{}) Row {
+	r.id = id
+
+	return r
+}
+
+// ID returns the ID that was set with WithID, or nil if none was set.
+func (r Row) ID() interface{} {
+	return r.id
+}
@@ -0,0 +1,139 @@
+package table
+
+// highlightedRowID returns the ID of the highlighted row, or nil if there are
+// no rows or the highlighted row has no ID.
+func (m *Model) highlightedRowID() interface{} {
+	if m.rowCursorIndex < 0 || m.rowCursorIndex >= m.visibleRowCount() {
+		return nil
+	}
+
+	return m.visibleRowAt(m.rowCursorIndex).id
+}
+
+// highlightRowByID moves the highlight to the visible row with the given ID.
+// Returns false and leaves the highlight alone if no visible row has the ID.
+func (m *Model) highlightRowByID(id interface{}) bool {
+	// Finding the row would mean fetching every row from a data source
+	if id == nil || m.dataSource != nil {
+		return false
+	}
+
+	for i, row := range m.GetVisibleRows() {
+		if row.id == id {
+			m.rowCursorIndex = i
+			m.currentPage = m.expectedPageForRowIndex(i)
+			m.updateVerticalScroll()
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// sourceIndexByID returns the index in the full list of rows of the row with
+// the given ID, or -1 if there is no such row.
+func (m *Model) sourceIndexByID(id interface{}) int {
+	if id == nil {
+		return -1
+	}
+
+	for i, row := range m.rows {
+		if row.id == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// HighlightRowByID highlights the row with the given ID, moving to its page if
+// the table is paginated.  If no currently visible row has the ID, such as when
+// it's hidden by a filter, the highlighted row does not change.
+func (m Model) HighlightRowByID(id interface{}) Model {
+	m.highlightRowByID(id)
+
+	return m
+}
+
+// UpdateRow replaces the row with the given ID with the result of the given
+// function, which receives the current row.  If no row has the ID, nothing
+// changes.  The original row data is not modified unless the function does so.
+func (m Model) UpdateRow(id interface{}, update func(row Row) Row) Model {
+	index := m.sourceIndexByID(id)
+
+	if index < 0 {
+		return m
+	}
+
+	rows := make([]Row, len(m.rows))
+	copy(rows, m.rows)
+
+	rows[index] = update(rows[index])
+
+	return m.WithRows(rows)
+}
+
+// UpsertRows replaces any existing rows that have the same ID as the given
+// rows, keeping their position, and adds any other rows to the end.  Replaced
+// rows keep their selection.
+func (m Model) UpsertRows(rows ...Row) Model {
+	if m.dataSource != nil {
+		return m
+	}
+
+	updated := make([]Row, len(m.rows), len(m.rows)+len(rows))
+	copy(updated, m.rows)
+
+	existing := map[interface{}]int{}
+
+	for i, row := range updated {
+		if row.id != nil {
+			existing[row.id] = i
+		}
+	}
+
+	for _, row := range rows {
+		if index, exists := existing[row.id]; exists && row.id != nil {
+			updated[index] = row
+
+			continue
+		}
+
+		if row.id != nil {
+			existing[row.id] = len(updated)
+		}
+
+		updated = append(updated, row)
+	}
+
+	return m.WithRows(updated)
+}
+
+// RemoveRows removes all rows with any of the given IDs.  If the highlighted
+// row is removed, the highlight stays at the same index.
+func (m Model) RemoveRows(ids ...interface{}) Model {
+	if m.dataSource != nil {
+		return m
+	}
+
+	removed := make(map[interface{}]struct{}, len(ids))
+
+	for _, id := range ids {
+		removed[id] = struct{}{}
+	}
+
+	rows := make([]Row, 0, len(m.rows))
+
+	for _, row := range m.rows {
+		if row.id != nil {
+			if _, isRemoved := removed[row.id]; isRemoved {
+				continue
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return m.WithRows(rows)
+}
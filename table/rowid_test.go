@@ -0,0 +1,176 @@
+package table
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func rowIDTestRows(names ...string) []Row {
+	rows := []Row{}
+
+	for _, name := range names {
+		rows = append(rows, NewRow(RowData{"name": name}).WithID(name))
+	}
+
+	return rows
+}
+
+func rowIDTestModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 8).WithFiltered(true),
+	}).WithRows(rowIDTestRows("b", "d", "a", "c")).SelectableRows(true).Focused(true)
+}
+
+func visibleNames(model Model) []string {
+	names := []string{}
+
+	for _, row := range model.GetVisibleRows() {
+		names = append(names, row.Data["name"].(string))
+	}
+
+	return names
+}
+
+func TestRowWithID(t *testing.T) {
+	row := NewRow(RowData{})
+
+	assert.Nil(t, row.ID())
+
+	withID := row.WithID(3)
+
+	assert.Equal(t, 3, withID.ID())
+	assert.Nil(t, row.ID(), "Should not modify the original row")
+}
+
+func TestHighlightRowByID(t *testing.T) {
+	model := rowIDTestModel().WithPageSize(2)
+
+	model = model.HighlightRowByID("c")
+
+	assert.Equal(t, 3, model.GetHighlightedRowIndex())
+	assert.Equal(t, 2, model.CurrentPage())
+
+	model = model.HighlightRowByID("missing")
+
+	assert.Equal(t, 3, model.GetHighlightedRowIndex(), "Should not move for a missing ID")
+}
+
+func TestHighlightFollowsRowWhenSorted(t *testing.T) {
+	model := rowIDTestModel().HighlightRowByID("d")
+
+	model = model.SortByAsc("name")
+
+	assert.Equal(t, []string{"a", "b", "c", "d"}, visibleNames(model))
+	assert.Equal(t, "d", model.HighlightedRow().ID())
+
+	// Interactive sorting should also follow the row
+	model, _ = model.WithCellCursor(true).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+
+	assert.Equal(t, []string{"d", "c", "b", "a"}, visibleNames(model))
+	assert.Equal(t, "d", model.HighlightedRow().ID())
+}
+
+func TestHighlightAndSelectionFollowRowWhenRefreshed(t *testing.T) {
+	model := rowIDTestModel().HighlightRowByID("a")
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	// Entirely new data, in a new order
+	model = model.WithRows(rowIDTestRows("a", "new", "c", "b"))
+
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+	assert.Equal(t, "a", model.HighlightedRow().ID())
+	assert.Len(t, model.SelectedRows(), 1)
+	assert.Equal(t, "a", model.SelectedRows()[0].ID())
+}
+
+func TestUpdateRow(t *testing.T) {
+	model := rowIDTestModel().SortByAsc("name").HighlightRowByID("b")
+
+	model = model.UpdateRow("b", func(row Row) Row {
+		return NewRow(RowData{"name": "z"}).WithID(row.ID())
+	})
+
+	assert.Equal(t, []string{"a", "c", "d", "z"}, visibleNames(model))
+	assert.Equal(t, "b", model.HighlightedRow().ID(), "Should follow the updated row")
+
+	unchanged := model.UpdateRow("missing", func(row Row) Row {
+		assert.Fail(t, "Should not be called for a missing row")
+
+		return row
+	})
+
+	assert.Equal(t, []string{"a", "c", "d", "z"}, visibleNames(unchanged))
+}
+
+func TestUpsertRows(t *testing.T) {
+	model := rowIDTestModel().HighlightRowByID("a")
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	model = model.UpsertRows(
+		NewRow(RowData{"name": "A"}).WithID("a"),
+		NewRow(RowData{"name": "e"}).WithID("e"),
+		NewRow(RowData{"name": "no id"}),
+	)
+
+	assert.Equal(t, []string{"b", "d", "A", "c", "e", "no id"}, visibleNames(model))
+	assert.Equal(t, "a", model.HighlightedRow().ID())
+	assert.Len(t, model.SelectedRows(), 1, "Replaced row should keep its selection")
+	assert.Equal(t, "A", model.SelectedRows()[0].Data["name"])
+}
+
+func TestRemoveRows(t *testing.T) {
+	model := rowIDTestModel().HighlightRowByID("d")
+
+	model = model.RemoveRows("b", "missing")
+
+	assert.Equal(t, []string{"d", "a", "c"}, visibleNames(model))
+	assert.Equal(t, "d", model.HighlightedRow().ID())
+
+	model = model.RemoveRows("d")
+
+	assert.Equal(t, []string{"a", "c"}, visibleNames(model))
+	assert.Equal(t, 0, model.GetHighlightedRowIndex(), "Should stay at the same index")
+
+	model = model.RemoveRows("a", "c")
+
+	assert.Empty(t, visibleNames(model))
+}
+
+func TestKeyedUpdatesWithFilter(t *testing.T) {
+	model := rowIDTestModel().Filtered(true).WithFilterInputValue("c")
+
+	model = model.UpdateRow("a", func(row Row) Row {
+		row.Data = RowData{"name": "ac"}
+
+		return row
+	})
+
+	assert.Equal(t, []string{"ac", "c"}, visibleNames(model))
+
+	model = model.WithFilterInputValue("")
+
+	assert.Equal(t, []string{"b", "d", "ac", "c"}, visibleNames(model), "Hidden rows should not be dropped")
+}
+
+func BenchmarkWithRowsWithIDs(b *testing.B) {
+	const N = 1000
+
+	rows := make([]Row, N)
+
+	for i := range rows {
+		rows[i] = NewRow(RowData{"name": fmt.Sprintf("%d", i)}).WithID(i).Selected(i%2 == 0)
+	}
+
+	model := New([]Column{NewColumn("name", "Name", 8)}).WithRows(rows).WithHighlightedRow(N / 2)
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		model = model.WithRows(rows)
+	}
+}
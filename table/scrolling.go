@@ -12,6 +12,34 @@ func (m *Model) scrollLeft() {
 	}
 }
 
+// scrollToHighlightedColumn scrolls horizontally until the highlighted cell
+// is fully visible.
+func (m *Model) scrollToHighlightedColumn() {
+	if !m.cellCursor {
+		return
+	}
+
+	m.scrollToColumn(m.columnCursorIndex)
+}
+
+// scrollToColumn scrolls horizontally until the given column is fully visible.
+// Frozen columns are always visible.
+func (m *Model) scrollToColumn(columnIndex int) {
+	if columnIndex < m.horizontalScrollFreezeColumnsCount {
+		return
+	}
+
+	for m.horizontalScrollOffsetCol > 0 &&
+		columnIndex < m.horizontalScrollOffsetCol+m.horizontalScrollFreezeColumnsCount {
+		m.scrollLeft()
+	}
+
+	for m.horizontalScrollOffsetCol < m.maxHorizontalColumnIndex &&
+		!m.isColumnRendered(columnIndex) {
+		m.scrollRight()
+	}
+}
+
 func (m *Model) recalculateLastHorizontalColumn() {
 	if m.horizontalScrollFreezeColumnsCount >= len(m.columns) {
 		m.maxHorizontalColumnIndex = 0
@@ -19,7 +47,7 @@ func (m *Model) recalculateLastHorizontalColumn() {
 		return
 	}
 
-	if m.totalWidth <= m.maxTotalWidth {
+	if m.totalWidth <= m.maxTableWidth() {
 		m.maxHorizontalColumnIndex = 0
 
 		return
@@ -40,11 +68,90 @@ func (m *Model) recalculateLastHorizontalColumn() {
 	m.maxHorizontalColumnIndex = len(m.columns) - 1
 
 	// Work backwards from the right
-	for i := len(m.columns) - 1; i >= m.horizontalScrollFreezeColumnsCount && visibleWidth <= m.maxTotalWidth; i-- {
+	for i := len(m.columns) - 1; i >= m.horizontalScrollFreezeColumnsCount && visibleWidth <= m.maxTableWidth(); i-- {
 		visibleWidth += m.columns[i].width + borderAdjustment
 
-		if visibleWidth <= m.maxTotalWidth {
+		if visibleWidth <= m.maxTableWidth() {
 			m.maxHorizontalColumnIndex = i - m.horizontalScrollFreezeColumnsCount
 		}
 	}
 }
+
+// renderedColumn describes where a column is drawn horizontally, as the range
+// [start, end) including its borders.
+type renderedColumn struct {
+	index int
+	start int
+	end   int
+}
+
+// renderedColumnLayout returns the positions of all columns that are fully
+// rendered with the current horizontal scroll, not including any overflow
+// indicators.  This mirrors the logic in renderRowData, so any changes there
+// should be reflected here.
+func (m *Model) renderedColumnLayout() []renderedColumn {
+	const (
+		borderAdjustment = 1
+		overflowColWidth = 2
+	)
+
+	layout := []renderedColumn{}
+	totalRenderedWidth := 0
+
+	for columnIndex, column := range m.columns {
+		if m.horizontalScrollOffsetCol > 0 && columnIndex == m.horizontalScrollFreezeColumnsCount {
+			// The left overflow indicator
+			renderedWidth := 1 + borderAdjustment
+
+			if totalRenderedWidth == 0 {
+				renderedWidth += borderAdjustment
+			}
+
+			totalRenderedWidth += renderedWidth
+		}
+
+		if columnIndex >= m.horizontalScrollFreezeColumnsCount &&
+			columnIndex < m.horizontalScrollOffsetCol+m.horizontalScrollFreezeColumnsCount {
+			continue
+		}
+
+		renderedWidth := column.width + borderAdjustment
+
+		if totalRenderedWidth == 0 {
+			renderedWidth += borderAdjustment
+		}
+
+		if m.maxTableWidth() != 0 {
+			targetWidth := m.maxTableWidth() - overflowColWidth
+
+			if columnIndex == len(m.columns)-1 {
+				targetWidth = m.maxTableWidth()
+			}
+
+			if totalRenderedWidth+renderedWidth > targetWidth {
+				// Everything else is hidden behind the right overflow indicator
+				break
+			}
+		}
+
+		layout = append(layout, renderedColumn{
+			index: columnIndex,
+			start: totalRenderedWidth,
+			end:   totalRenderedWidth + renderedWidth,
+		})
+
+		totalRenderedWidth += renderedWidth
+	}
+
+	return layout
+}
+
+func (m *Model) isColumnRendered(columnIndex int) bool {
+	for _, rendered := range m.renderedColumnLayout() {
+		if rendered.index == columnIndex {
+			return true
+		}
+	}
+
+	return false
+}
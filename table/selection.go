@@ -0,0 +1,283 @@
+package table
+
+import "reflect"
+
+// rowIdentity returns a key that identifies the same row across changes to the
+// order of rows, or nil if the row can't be identified.  Rows are considered
+// the same if they have the same ID, or if they share the same underlying data
+// when they have no ID.
+func rowIdentity(row Row) interface{} {
+	if row.id != nil {
+		return row.id
+	}
+
+	if row.Data == nil {
+		return nil
+	}
+
+	return reflect.ValueOf(row.Data).Pointer()
+}
+
+// keepSelection returns the new rows with any rows that were selected in the
+// old rows also selected, unless the new row's selection was set explicitly
+// with Row.Selected.  The new rows are only copied if something changes.
+func keepSelection(oldRows, newRows []Row) []Row {
+	selected := map[interface{}]struct{}{}
+
+	for _, row := range oldRows {
+		if row.selected {
+			if identity := rowIdentity(row); identity != nil {
+				selected[identity] = struct{}{}
+			}
+		}
+	}
+
+	if len(selected) == 0 {
+		return newRows
+	}
+
+	var rows []Row
+
+	for i, row := range newRows {
+		if row.selected || row.selectedSet {
+			continue
+		}
+
+		identity := rowIdentity(row)
+
+		if identity == nil {
+			continue
+		}
+
+		if _, wasSelected := selected[identity]; !wasSelected {
+			continue
+		}
+
+		if rows == nil {
+			rows = make([]Row, len(newRows))
+			copy(rows, newRows)
+		}
+
+		rows[i].selected = true
+	}
+
+	if rows == nil {
+		return newRows
+	}
+
+	return rows
+}
+
+// setVisibleRowsSelected applies the given selection function to every visible
+// row between the given indices, inclusive, and returns which rows changed.
+// Changes are written back to the full list of rows so that rows hidden by a
+// filter are kept.
+func (m *Model) setVisibleRowsSelected(startIndex, endIndex int, selectFunc func(selected bool) bool) UserEventRowsSelectionChanged {
+	changed := UserEventRowsSelectionChanged{}
+
+	if m.dataSource != nil {
+		return changed
+	}
+
+	visibleRows := m.GetVisibleRows()
+
+	startIndex = max(startIndex, 0)
+	endIndex = min(endIndex, len(visibleRows)-1)
+
+	if startIndex > endIndex {
+		return changed
+	}
+
+	var rows []Row
+
+	for i := startIndex; i <= endIndex; i++ {
+		current := visibleRows[i].selected
+		updated := selectFunc(current)
+
+		if current == updated {
+			continue
+		}
+
+		// Only copy if something actually changed
+		if rows == nil {
+			rows = make([]Row, len(m.rows))
+			copy(rows, m.rows)
+		}
+
+		rows[m.visibleRowSourceIndex(i)].selected = updated
+
+		if updated {
+			changed.SelectedRowIndices = append(changed.SelectedRowIndices, i)
+		} else {
+			changed.DeselectedRowIndices = append(changed.DeselectedRowIndices, i)
+		}
+	}
+
+	if rows != nil {
+		m.rows = rows
+		m.invalidateSelectedRows()
+	}
+
+	return changed
+}
+
+func (m *Model) setAllVisibleRowsSelected(selectFunc func(selected bool) bool) UserEventRowsSelectionChanged {
+	return m.setVisibleRowsSelected(0, m.visibleRowCount()-1, selectFunc)
+}
+
+func selectAlways(bool) bool {
+	return true
+}
+
+func selectNever(bool) bool {
+	return false
+}
+
+func selectInverted(selected bool) bool {
+	return !selected
+}
+
+// selectionAnchor returns the row that range selection starts from, which is
+// the last row that was toggled or the highlighted row if there is none.
+func (m *Model) selectionAnchor() int {
+	if m.selectionAnchorIndex < 0 || m.selectionAnchorIndex >= m.visibleRowCount() {
+		return m.rowCursorIndex
+	}
+
+	return m.selectionAnchorIndex
+}
+
+// selectRangeToCursor selects every row between the anchor and the highlighted
+// row, and deselects any rows that were in the last range from the same anchor
+// but aren't anymore, such as when moving back towards the anchor.
+func (m *Model) selectRangeToCursor(anchor int) UserEventRowsSelectionChanged {
+	start := min(anchor, m.rowCursorIndex)
+	end := max(anchor, m.rowCursorIndex)
+
+	changed := UserEventRowsSelectionChanged{}
+
+	if anchor == m.selectionAnchorIndex && m.selectionRangeEndIndex >= 0 {
+		previousStart := min(anchor, m.selectionRangeEndIndex)
+		previousEnd := max(anchor, m.selectionRangeEndIndex)
+
+		before := m.setVisibleRowsSelected(previousStart, min(previousEnd, start-1), selectNever)
+		after := m.setVisibleRowsSelected(max(previousStart, end+1), previousEnd, selectNever)
+
+		changed.DeselectedRowIndices = append(before.DeselectedRowIndices, after.DeselectedRowIndices...)
+	}
+
+	m.selectionAnchorIndex = anchor
+	m.selectionRangeEndIndex = m.rowCursorIndex
+
+	changed.SelectedRowIndices = m.setVisibleRowsSelected(start, end, selectAlways).SelectedRowIndices
+
+	return changed
+}
+
+// userSelectRows applies a bulk selection change from user input and emits a
+// single event for the whole change, if anything changed.
+func (m *Model) userSelectRows(change func() UserEventRowsSelectionChanged) {
+	if !m.selectableRows {
+		return
+	}
+
+	changed := change()
+
+	if len(changed.SelectedRowIndices) > 0 || len(changed.DeselectedRowIndices) > 0 {
+		m.appendUserEvent(changed)
+	}
+}
+
+func (m *Model) userSelectAll() {
+	m.userSelectRows(func() UserEventRowsSelectionChanged {
+		return m.setAllVisibleRowsSelected(selectAlways)
+	})
+}
+
+func (m *Model) userSelectNone() {
+	m.userSelectRows(func() UserEventRowsSelectionChanged {
+		// Only visible rows are reported, but hidden rows are cleared too
+		changed := m.setAllVisibleRowsSelected(selectNever)
+		m.deselectAllRows()
+
+		return changed
+	})
+}
+
+func (m *Model) userSelectInvert() {
+	m.userSelectRows(func() UserEventRowsSelectionChanged {
+		return m.setAllVisibleRowsSelected(selectInverted)
+	})
+}
+
+func (m *Model) userSelectPage() {
+	m.userSelectRows(func() UserEventRowsSelectionChanged {
+		start, end := m.VisibleIndices()
+
+		return m.setVisibleRowsSelected(start, end, selectAlways)
+	})
+}
+
+// userSelectRange moves the highlighted row with the given function and then
+// selects every row between the anchor and the new highlighted row.
+func (m *Model) userSelectRange(move func()) {
+	if !m.selectableRows || m.visibleRowCount() == 0 {
+		return
+	}
+
+	anchor := m.selectionAnchor()
+
+	move()
+
+	m.userSelectRows(func() UserEventRowsSelectionChanged {
+		return m.selectRangeToCursor(anchor)
+	})
+}
+
+// deselectAllRows deselects every row, including rows hidden by a filter.
+func (m *Model) deselectAllRows() {
+	rows := make([]Row, len(m.rows))
+	copy(rows, m.rows)
+
+	for i := range rows {
+		rows[i].selected = false
+	}
+
+	m.rows = rows
+	m.invalidateSelectedRows()
+}
+
+// WithAllRowsSelected selects all rows that are currently visible, which
+// excludes any rows that are hidden by a filter.
+func (m Model) WithAllRowsSelected() Model {
+	m.setAllVisibleRowsSelected(selectAlways)
+
+	return m
+}
+
+// WithSelectionInverted selects all currently visible rows that are not
+// selected and deselects all currently visible rows that are selected.  Rows
+// hidden by a filter are not changed.
+func (m Model) WithSelectionInverted() Model {
+	m.setAllVisibleRowsSelected(selectInverted)
+
+	return m
+}
+
+// WithCurrentPageSelected selects all rows on the current page.  If there is no
+// pagination, all rows that are currently in view are selected.
+func (m Model) WithCurrentPageSelected() Model {
+	start, end := m.VisibleIndices()
+
+	m.setVisibleRowsSelected(start, end, selectAlways)
+
+	return m
+}
+
+// WithRowRangeSelected selects all visible rows between the two given row
+// indices, inclusive.  The indices can be given in either order.
+func (m Model) WithRowRangeSelected(fromRowIndex, toRowIndex int) Model {
+	m.setVisibleRowsSelected(min(fromRowIndex, toRowIndex), max(fromRowIndex, toRowIndex), selectAlways)
+
+	return m
+}
@@ -0,0 +1,263 @@
+package table
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func selectionTestModel() Model {
+	rows := []Row{}
+
+	for i := 0; i < 5; i++ {
+		rows = append(rows, NewRow(RowData{"id": i}))
+	}
+
+	return New([]Column{
+		NewColumn("id", "ID", 3).WithFiltered(true),
+	}).WithRows(rows).SelectableRows(true).Focused(true)
+}
+
+func selectedIDs(model Model) []int {
+	ids := []int{}
+
+	for _, row := range model.SelectedRows() {
+		ids = append(ids, row.Data["id"].(int))
+	}
+
+	return ids
+}
+
+func TestSelectAllNoneInvertKeys(t *testing.T) {
+	model := selectionTestModel()
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, selectedIDs(model))
+	assert.Equal(t, []UserEvent{
+		UserEventRowsSelectionChanged{SelectedRowIndices: []int{0, 1, 2, 3, 4}},
+	}, model.GetLastUpdateUserEvents())
+
+	// Nothing changes, so no event
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlN})
+
+	assert.Empty(t, selectedIDs(model))
+	assert.Equal(t, []UserEvent{
+		UserEventRowsSelectionChanged{DeselectedRowIndices: []int{0, 1, 2, 3, 4}},
+	}, model.GetLastUpdateUserEvents())
+
+	model = model.WithRowRangeSelected(3, 1)
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'*'}})
+
+	assert.Equal(t, []int{0, 4}, selectedIDs(model))
+	assert.Equal(t, []UserEvent{
+		UserEventRowsSelectionChanged{
+			SelectedRowIndices:   []int{0, 4},
+			DeselectedRowIndices: []int{1, 2, 3},
+		},
+	}, model.GetLastUpdateUserEvents())
+}
+
+func TestSelectPageKey(t *testing.T) {
+	model := selectionTestModel().WithPageSize(2).PageDown()
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+
+	assert.Equal(t, []int{2, 3}, selectedIDs(model))
+	assert.Equal(t, []UserEvent{
+		UserEventRowsSelectionChanged{SelectedRowIndices: []int{2, 3}},
+	}, model.GetLastUpdateUserEvents())
+}
+
+func TestSelectRangeKeys(t *testing.T) {
+	model := selectionTestModel().WithHighlightedRow(1)
+
+	// Without an anchor, the range starts from the highlighted row
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+
+	assert.Equal(t, []int{1, 2}, selectedIDs(model))
+	assert.Equal(t, 2, model.GetHighlightedRowIndex())
+
+	events := model.GetLastUpdateUserEvents()
+
+	assert.Contains(t, events, UserEventRowsSelectionChanged{SelectedRowIndices: []int{1, 2}})
+	assert.Contains(t, events, UserEventHighlightedIndexChanged{PreviousRowIndex: 1, SelectedRowIndex: 2})
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+
+	assert.Equal(t, []int{1, 2, 3}, selectedIDs(model))
+	assert.Contains(t, model.GetLastUpdateUserEvents(), UserEventRowsSelectionChanged{SelectedRowIndices: []int{3}})
+
+	// Toggling sets a new anchor
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyUp})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyUp})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyUp})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyShiftUp})
+
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, selectedIDs(model))
+	assert.Contains(t, model.GetLastUpdateUserEvents(), UserEventRowsSelectionChanged{SelectedRowIndices: []int{0}})
+}
+
+func TestSelectRangeKeysShrinkTowardsAnchor(t *testing.T) {
+	model := selectionTestModel().WithHighlightedRow(1)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+
+	assert.Equal(t, []int{1, 2, 3, 4}, selectedIDs(model))
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyShiftUp})
+
+	assert.Equal(t, []int{1, 2, 3}, selectedIDs(model))
+	assert.Contains(t, model.GetLastUpdateUserEvents(), UserEventRowsSelectionChanged{DeselectedRowIndices: []int{4}})
+
+	// Crossing over the anchor drops the rows on the other side
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyShiftUp})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyShiftUp})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyShiftUp})
+
+	assert.Equal(t, []int{0, 1}, selectedIDs(model))
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+
+	assert.Equal(t, []int{1, 2}, selectedIDs(model))
+}
+
+func TestSelectKeysIgnoredWhenNotSelectable(t *testing.T) {
+	model := selectionTestModel().SelectableRows(false)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+
+	assert.Empty(t, selectedIDs(model))
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+}
+
+func TestSelectAllOnlyAffectsVisibleRows(t *testing.T) {
+	model := selectionTestModel().Filtered(true).WithFilterInputValue("3")
+
+	model = model.WithAllRowsSelected().WithFilterInputValue("")
+
+	assert.Equal(t, []int{3}, selectedIDs(model))
+	assert.Len(t, model.GetVisibleRows(), 5, "Should not drop hidden rows")
+
+	model = model.WithSelectionInverted()
+
+	assert.Equal(t, []int{0, 1, 2, 4}, selectedIDs(model))
+}
+
+func TestSelectNoneKeyClearsHiddenRows(t *testing.T) {
+	model := selectionTestModel().
+		WithAllRowsSelected().
+		Filtered(true).
+		WithFilterInputValue("3")
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlN})
+
+	assert.Equal(t, []UserEvent{
+		UserEventRowsSelectionChanged{DeselectedRowIndices: []int{0}},
+	}, model.GetLastUpdateUserEvents())
+
+	model = model.WithFilterInputValue("")
+
+	assert.Empty(t, selectedIDs(model))
+}
+
+func TestWithCurrentPageSelected(t *testing.T) {
+	model := selectionTestModel().WithPageSize(2).WithCurrentPage(3)
+
+	model = model.WithCurrentPageSelected()
+
+	assert.Equal(t, []int{4}, selectedIDs(model))
+	assert.Empty(t, model.GetLastUpdateUserEvents(), "Code changes should not generate events")
+}
+
+func TestSelectionSurvivesFiltering(t *testing.T) {
+	model := selectionTestModel().Filtered(true)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	model = model.WithFilterInputValue("3")
+	assert.Len(t, model.GetVisibleRows(), 1)
+
+	// Toggling while filtered used to drop all the hidden rows
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	assert.Equal(t, []int{0, 3}, selectedIDs(model), "Should include rows hidden by the filter")
+
+	model = model.WithFilterInputValue("")
+
+	assert.Len(t, model.GetVisibleRows(), 5)
+	assert.Equal(t, []int{0, 3}, selectedIDs(model))
+
+	model = model.WithFilterInputValue("4").WithAllRowsDeselected().WithFilterInputValue("")
+
+	assert.Len(t, model.GetVisibleRows(), 5, "Deselecting should not drop hidden rows")
+	assert.Empty(t, selectedIDs(model))
+}
+
+func TestSelectionSurvivesSorting(t *testing.T) {
+	model := selectionTestModel().SortByDesc("id")
+
+	// Highlighted row is the last row in the original order
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	assert.Equal(t, []int{4}, selectedIDs(model))
+
+	model = model.SortByAsc("id")
+
+	assert.Equal(t, []int{4}, selectedIDs(model))
+	assert.True(t, model.GetVisibleRows()[4].selected)
+
+	model = model.WithRowRangeSelected(0, 1).SortByDesc("id")
+
+	assert.Equal(t, []int{4, 1, 0}, selectedIDs(model), "Should be in sorted order")
+}
+
+func TestSelectionSurvivesWithRows(t *testing.T) {
+	model := selectionTestModel()
+	rows := model.rows
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	// Same rows in a different order
+	model = model.WithRows([]Row{rows[4], rows[3], rows[2], rows[1], rows[0]})
+
+	assert.Equal(t, []int{0}, selectedIDs(model))
+	assert.False(t, rows[0].selected, "Should not modify the given rows")
+
+	// New rows with new data are not the same rows
+	model = model.WithRows([]Row{NewRow(RowData{"id": 0})})
+
+	assert.Empty(t, selectedIDs(model))
+}
+
+func TestWithRowsExplicitlyDeselected(t *testing.T) {
+	model := selectionTestModel()
+	rows := model.rows
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	assert.Len(t, model.SelectedRows(), 1)
+
+	model = model.WithRows([]Row{rows[0].Selected(false), rows[1]})
+
+	assert.Empty(t, model.SelectedRows())
+
+	// The same with IDs
+	model = model.WithRows([]Row{rows[0].WithID(0), rows[1].WithID(1)})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	assert.Len(t, model.SelectedRows(), 1)
+
+	model = model.WithRows([]Row{NewRow(RowData{"id": 0}).WithID(0).Selected(false)})
+
+	assert.Empty(t, model.SelectedRows())
+}
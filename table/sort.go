@@ -1,8 +1,9 @@
 package table
 
 import (
-	"fmt"
 	"sort"
+	"strings"
+	"unicode/utf8"
 )
 
 // SortDirection indicates whether a column should sort by ascending or descending.
@@ -22,19 +23,67 @@ type SortColumn struct {
 	Direction SortDirection
 }
 
+// MissingSort sets where rows without data for the sorted column go.
+type MissingSort int
+
+const (
+	// MissingSortAsEmpty sorts missing data as if it were an empty string.
+	// This is the default.
+	MissingSortAsEmpty MissingSort = iota
+
+	// MissingSortFirst puts rows with missing data first, in either direction.
+	MissingSortFirst
+
+	// MissingSortLast puts rows with missing data last, in either direction.
+	MissingSortLast
+)
+
+// sortOptions are the model's settings for how values are compared.
+type sortOptions struct {
+	natural         bool
+	caseInsensitive bool
+	missing         MissingSort
+}
+
+// WithNaturalSort sets whether text is sorted naturally, comparing runs of
+// digits by their numeric value so that "item2" comes before "item10".
+func (m Model) WithNaturalSort(natural bool) Model {
+	m.sortOptions.natural = natural
+	m.invalidateFilterAndSort()
+
+	return m
+}
+
+// WithCaseInsensitiveSort sets whether text is sorted ignoring case, so that
+// "apple" comes before "Zebra".
+func (m Model) WithCaseInsensitiveSort(caseInsensitive bool) Model {
+	m.sortOptions.caseInsensitive = caseInsensitive
+	m.invalidateFilterAndSort()
+
+	return m
+}
+
+// WithMissingSort sets where rows without data for a sorted column go.  This
+// also applies to columns with a sort function.  Defaults to
+// MissingSortAsEmpty.
+func (m Model) WithMissingSort(missing MissingSort) Model {
+	m.sortOptions.missing = missing
+	m.invalidateFilterAndSort()
+
+	return m
+}
+
 // SortByAsc sets the main sorting column to the given key, in ascending order.
 // If a previous sort was used, it is replaced by the given column each time
 // this function is called.  Values are sorted as numbers if possible, or just
 // as simple string comparisons if not numbers.
 func (m Model) SortByAsc(columnKey string) Model {
-	m.sortOrder = []SortColumn{
+	m.setSortOrder([]SortColumn{
 		{
 			ColumnKey: columnKey,
 			Direction: SortDirectionAsc,
 		},
-	}
-
-	m.visibleRowCacheUpdated = false
+	})
 
 	return m
 }
@@ -44,14 +93,12 @@ func (m Model) SortByAsc(columnKey string) Model {
 // this function is called.  Values are sorted as numbers if possible, or just
 // as simple string comparisons if not numbers.
 func (m Model) SortByDesc(columnKey string) Model {
-	m.sortOrder = []SortColumn{
+	m.setSortOrder([]SortColumn{
 		{
 			ColumnKey: columnKey,
 			Direction: SortDirectionDesc,
 		},
-	}
-
-	m.visibleRowCacheUpdated = false
+	})
 
 	return m
 }
@@ -59,14 +106,12 @@ func (m Model) SortByDesc(columnKey string) Model {
 // ThenSortByAsc provides a secondary sort after the first, in ascending order.
 // Can be chained multiple times, applying to smaller subgroups each time.
 func (m Model) ThenSortByAsc(columnKey string) Model {
-	m.sortOrder = append([]SortColumn{
+	m.setSortOrder(append([]SortColumn{
 		{
 			ColumnKey: columnKey,
 			Direction: SortDirectionAsc,
 		},
-	}, m.sortOrder...)
-
-	m.visibleRowCacheUpdated = false
+	}, m.sortOrder...))
 
 	return m
 }
@@ -74,104 +119,370 @@ func (m Model) ThenSortByAsc(columnKey string) Model {
 // ThenSortByDesc provides a secondary sort after the first, in descending order.
 // Can be chained multiple times, applying to smaller subgroups each time.
 func (m Model) ThenSortByDesc(columnKey string) Model {
-	m.sortOrder = append([]SortColumn{
+	m.setSortOrder(append([]SortColumn{
 		{
 			ColumnKey: columnKey,
 			Direction: SortDirectionDesc,
 		},
-	}, m.sortOrder...)
-
-	m.visibleRowCacheUpdated = false
+	}, m.sortOrder...))
 
 	return m
 }
 
+// setSortOrder changes the sort order, keeping the highlighted row if it has
+// an ID.
+func (m *Model) setSortOrder(sortOrder []SortColumn) {
+	highlightedID := m.highlightedRowID()
+
+	m.sortOrder = sortOrder
+	m.invalidateFilterAndSort()
+
+	m.highlightRowByID(highlightedID)
+
+	if m.following {
+		m.pinToNewestRow()
+	}
+}
+
+// setSortOrderFromUser applies a sort order that the user chose interactively.
+func (m *Model) setSortOrderFromUser(sortOrder []SortColumn) {
+	m.setSortOrder(sortOrder)
+
+	m.appendUserEvent(UserEventSortChanged{
+		SortColumns: m.GetColumnSorting(),
+	})
+}
+
+// cycleSortColumn moves the given column through ascending, descending, and
+// unsorted.  If keepOthers is false, any other sorting is replaced.
+func (m *Model) cycleSortColumn(columnKey string, keepOthers bool) {
+	if !keepOthers {
+		if len(m.sortOrder) == 1 && m.sortOrder[0].ColumnKey == columnKey {
+			if m.sortOrder[0].Direction == SortDirectionAsc {
+				m.setSortOrderFromUser([]SortColumn{{ColumnKey: columnKey, Direction: SortDirectionDesc}})
+			} else {
+				m.setSortOrderFromUser(nil)
+			}
+
+			return
+		}
+
+		m.setSortOrderFromUser([]SortColumn{{ColumnKey: columnKey, Direction: SortDirectionAsc}})
+
+		return
+	}
+
+	newSortOrder := []SortColumn{}
+	found := false
+
+	for _, sortColumn := range m.sortOrder {
+		if sortColumn.ColumnKey != columnKey {
+			newSortOrder = append(newSortOrder, sortColumn)
+
+			continue
+		}
+
+		found = true
+
+		// Descending goes back to unsorted by not being added
+		if sortColumn.Direction == SortDirectionAsc {
+			newSortOrder = append(newSortOrder, SortColumn{
+				ColumnKey: columnKey,
+				Direction: SortDirectionDesc,
+			})
+		}
+	}
+
+	if !found {
+		// The first sort column is applied first, so it only breaks ties
+		newSortOrder = append([]SortColumn{{
+			ColumnKey: columnKey,
+			Direction: SortDirectionAsc,
+		}}, newSortOrder...)
+	}
+
+	m.setSortOrderFromUser(newSortOrder)
+}
+
+// toggleSortHighlightedColumn cycles the sorting of the highlighted column.
+// The highlighted column is only shown with the cell cursor, so without it
+// there's nothing for the user to have picked.
+func (m *Model) toggleSortHighlightedColumn(keepOthers bool) {
+	if !m.cellCursor || m.columnCursorIndex < 0 || m.columnCursorIndex >= len(m.columns) {
+		return
+	}
+
+	column := m.columns[m.columnCursorIndex]
+
+	if !column.sortable {
+		return
+	}
+
+	m.cycleSortColumn(column.key, keepOthers)
+}
+
+// moveSortColumn moves the highlighted column in the given direction to the
+// next column that can be sorted, if any.
+func (m *Model) moveSortColumn(step int) {
+	if !m.cellCursor {
+		return
+	}
+
+	for i := m.columnCursorIndex + step; i >= 0 && i < len(m.columns); i += step {
+		if m.columns[i].sortable {
+			m.columnCursorIndex = i
+			m.scrollToHighlightedColumn()
+
+			return
+		}
+	}
+}
+
+// sortKeyColumn is a column that rows are being sorted by, in order of priority.
+type sortKeyColumn struct {
+	key       string
+	direction SortDirection
+
+	// Compares the column's data instead of the default ordering if set
+	sortFunc SortFunc
+}
+
+// sortableTable sorts rows by all sorted columns at once, keeping equal rows in
+// their original order.  The values to sort by are read from every row once up
+// front, so that comparing rows doesn't need to look up and convert the same
+// data over and over.
 type sortableTable struct {
-	rows     []Row
-	byColumn SortColumn
+	// The order of the rows, as indices into the original rows
+	order []int
+
+	columns []sortKeyColumn
+
+	// One value per column for each row, with each row's values side by side
+	keys []sortValue
+
+	options sortOptions
+}
+
+func newSortableTable(columns []Column, sortOrder []SortColumn, options sortOptions, rows []Row) *sortableTable {
+	sorted := &sortableTable{
+		order:   make([]int, len(rows)),
+		columns: make([]sortKeyColumn, 0, len(sortOrder)),
+		keys:    make([]sortValue, 0, len(rows)*len(sortOrder)),
+		options: options,
+	}
+
+	// The last column in the sort order is the most important
+	for i := len(sortOrder) - 1; i >= 0; i-- {
+		keyColumn := sortKeyColumn{
+			key:       sortOrder[i].ColumnKey,
+			direction: sortOrder[i].Direction,
+		}
+
+		for _, column := range columns {
+			if column.key == sortOrder[i].ColumnKey {
+				keyColumn.sortFunc = column.sortFunc
+			}
+		}
+
+		sorted.columns = append(sorted.columns, keyColumn)
+	}
+
+	for i, row := range rows {
+		sorted.order[i] = i
+		sorted.keys = sorted.appendKeys(sorted.keys, row)
+	}
+
+	return sorted
+}
+
+// appendKeys appends the values to sort the row by, one per sorted column.
+func (s *sortableTable) appendKeys(keys []sortValue, row Row) []sortValue {
+	for _, column := range s.columns {
+		data, exists := row.Data[column.key]
+
+		if !exists {
+			keys = append(keys, sortValue{kind: sortValueMissing})
+
+			continue
+		}
+
+		keys = append(keys, toSortValue(data, s.options))
+	}
+
+	return keys
+}
+
+// readKeys returns the values to sort a row by that isn't in the table.
+func (s *sortableTable) readKeys(row Row) []sortValue {
+	return s.appendKeys(make([]sortValue, 0, len(s.columns)), row)
+}
+
+// rowKeys returns the values to sort the row at the given index in the
+// original rows by.
+func (s *sortableTable) rowKeys(index int) []sortValue {
+	numColumns := len(s.columns)
+
+	return s.keys[index*numColumns : (index+1)*numColumns]
 }
 
 func (s *sortableTable) Len() int {
-	return len(s.rows)
+	return len(s.order)
 }
 
 func (s *sortableTable) Swap(i, j int) {
-	old := s.rows[i]
-	s.rows[i] = s.rows[j]
-	s.rows[j] = old
+	s.order[i], s.order[j] = s.order[j], s.order[i]
 }
 
-func (s *sortableTable) extractString(i int, column string) string {
-	iData, exists := s.rows[i].Data[column]
-
-	if !exists {
-		return ""
+func (s *sortableTable) Less(first, second int) bool {
+	if compared := s.compareKeys(s.rowKeys(s.order[first]), s.rowKeys(s.order[second])); compared != 0 {
+		return compared < 0
 	}
 
-	switch iData := iData.(type) {
-	case StyledCell:
-		return fmt.Sprintf("%v", iData.Data)
+	// Keep equal rows in their original order
+	return s.order[first] < s.order[second]
+}
+
+// lessKeys returns true if a row with the first keys comes before a row with
+// the second keys.  Rows with equal keys are not less than each other.
+func (s *sortableTable) lessKeys(first, second []sortValue) bool {
+	return s.compareKeys(first, second) < 0
+}
+
+// compareKeys returns a negative number if a row with the first keys comes
+// first, a positive number if a row with the second keys comes first, or 0 if
+// they are equal in every sorted column.
+func (s *sortableTable) compareKeys(first, second []sortValue) int {
+	for i, column := range s.columns {
+		compared, decided := s.compare(column, first[i], second[i])
+
+		switch {
+		case compared == 0:
+			continue
 
-	case string:
-		return iData
+		case decided || column.direction == SortDirectionAsc:
+			return compared
 
-	default:
-		return fmt.Sprintf("%v", iData)
+		default:
+			return -compared
+		}
 	}
+
+	return 0
 }
 
-func (s *sortableTable) extractNumber(i int, column string) (float64, bool) {
-	iData, exists := s.rows[i].Data[column]
+// compare returns how the values compare in ascending order.  If the order is
+// decided regardless of the column's direction, such as by where missing data
+// goes, then it also returns true.
+func (s *sortableTable) compare(column sortKeyColumn, first, second sortValue) (int, bool) {
+	if s.options.missing != MissingSortAsEmpty {
+		firstMissing := first.kind == sortValueMissing
+		secondMissing := second.kind == sortValueMissing
+
+		if firstMissing && secondMissing {
+			return 0, false
+		}
 
-	if !exists {
-		return 0, false
+		if firstMissing || secondMissing {
+			if firstMissing == (s.options.missing == MissingSortFirst) {
+				return -1, true
+			}
+
+			return 1, true
+		}
+	}
+
+	if column.sortFunc != nil {
+		return column.sortFunc(first.data, second.data), false
 	}
 
-	return asNumber(iData)
+	return compareSortValues(first, second, s.options), false
 }
 
-func (s *sortableTable) Less(first, second int) bool {
-	firstNum, firstNumIsValid := s.extractNumber(first, s.byColumn.ColumnKey)
-	secondNum, secondNumIsValid := s.extractNumber(second, s.byColumn.ColumnKey)
+// compareText compares text that has already been case folded if needed,
+// returning a negative number if a comes first, a positive number if b comes
+// first, or 0 if they are equal.
+func compareText(a, b string, options sortOptions) int {
+	if options.natural {
+		return compareNatural(a, b)
+	}
+
+	return strings.Compare(a, b)
+}
 
-	if firstNumIsValid && secondNumIsValid {
-		if s.byColumn.Direction == SortDirectionAsc {
-			return firstNum < secondNum
+// compareNatural compares text where any runs of digits are compared by their
+// numeric value, so that "item2" comes before "item10".  If two numbers are
+// equal but one has more leading zeroes, the one with fewer comes first.
+func compareNatural(a, b string) int {
+	for a != "" && b != "" {
+		aDigits := leadingDigits(a)
+		bDigits := leadingDigits(b)
+
+		if aDigits == "" || bDigits == "" {
+			aRune, aSize := utf8.DecodeRuneInString(a)
+			bRune, bSize := utf8.DecodeRuneInString(b)
+
+			if aRune != bRune {
+				if aRune < bRune {
+					return -1
+				}
+
+				return 1
+			}
+
+			a = a[aSize:]
+			b = b[bSize:]
+
+			continue
+		}
+
+		aTrimmed := strings.TrimLeft(aDigits, "0")
+		bTrimmed := strings.TrimLeft(bDigits, "0")
+
+		// Without leading zeroes, a longer number is always larger
+		if len(aTrimmed) != len(bTrimmed) {
+			return len(aTrimmed) - len(bTrimmed)
 		}
 
-		return firstNum > secondNum
+		if compared := strings.Compare(aTrimmed, bTrimmed); compared != 0 {
+			return compared
+		}
+
+		if len(aDigits) != len(bDigits) {
+			return len(aDigits) - len(bDigits)
+		}
+
+		a = a[len(aDigits):]
+		b = b[len(bDigits):]
 	}
 
-	firstVal := s.extractString(first, s.byColumn.ColumnKey)
-	secondVal := s.extractString(second, s.byColumn.ColumnKey)
+	return len(a) - len(b)
+}
+
+// leadingDigits returns the ASCII digits at the start of the string.
+func leadingDigits(str string) string {
+	i := 0
 
-	if s.byColumn.Direction == SortDirectionAsc {
-		return firstVal < secondVal
+	for i < len(str) && str[i] >= '0' && str[i] <= '9' {
+		i++
 	}
 
-	return firstVal > secondVal
+	return str[:i]
 }
 
-func getSortedRows(sortOrder []SortColumn, rows []Row) []Row {
-	var sortedRows []Row
+func getSortedRows(columns []Column, sortOrder []SortColumn, options sortOptions, rows []Row) []Row {
 	if len(sortOrder) == 0 {
-		sortedRows = rows
-
-		return sortedRows
+		return rows
 	}
 
-	sortedRows = make([]Row, len(rows))
-	copy(sortedRows, rows)
+	sorted := newSortableTable(columns, sortOrder, options, rows)
 
-	for _, byColumn := range sortOrder {
-		sorted := &sortableTable{
-			rows:     sortedRows,
-			byColumn: byColumn,
-		}
+	// Equal rows are ordered by their original index, so this is stable
+	sort.Sort(sorted)
 
-		sort.Stable(sorted)
+	sortedRows := make([]Row, len(rows))
 
-		sortedRows = sorted.rows
+	for i, index := range sorted.order {
+		sortedRows[i] = rows[index]
 	}
 
 	return sortedRows
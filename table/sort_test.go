@@ -1,8 +1,11 @@
 package table
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
 )
@@ -157,7 +160,7 @@ func TestGetSortedRows(t *testing.T) {
 			Direction: SortDirectionAsc,
 		},
 	}
-	rows := getSortedRows(sortColumns, []Row{
+	rows := getSortedRows(nil, sortColumns, sortOptions{}, []Row{
 		NewRow(RowData{
 			"ca": "2",
 			"cb": "t-1",
@@ -186,3 +189,390 @@ func TestGetSortedRows(t *testing.T) {
 	assert.Equal(t, "t-3", rows[2].Data["cb"])
 	assert.Equal(t, "t-2", rows[3].Data["cb"])
 }
+
+func TestGetSortedRowsKeepsOriginalOrderOfEqualRows(t *testing.T) {
+	sortColumns := []SortColumn{
+		{ColumnKey: "group", Direction: SortDirectionDesc},
+		{ColumnKey: "score", Direction: SortDirectionAsc},
+	}
+
+	rows := []Row{}
+
+	for i := 0; i < 100; i++ {
+		rows = append(rows, NewRow(RowData{
+			"id":    i,
+			"score": i % 3,
+			"group": i % 2,
+		}))
+	}
+
+	sorted := getSortedRows(nil, sortColumns, sortOptions{}, rows)
+
+	assert.Len(t, sorted, len(rows))
+
+	for i := 1; i < len(sorted); i++ {
+		prev, cur := sorted[i-1].Data, sorted[i].Data
+
+		if prev["score"] != cur["score"] {
+			assert.Less(t, prev["score"], cur["score"])
+
+			continue
+		}
+
+		if prev["group"] != cur["group"] {
+			assert.Greater(t, prev["group"], cur["group"])
+
+			continue
+		}
+
+		assert.Less(t, prev["id"], cur["id"], "Equal rows should keep their original order")
+	}
+}
+
+func TestGetSortedRowsDoesNotModifyInput(t *testing.T) {
+	rows := []Row{
+		NewRow(RowData{"name": "b"}),
+		NewRow(RowData{"name": "a"}),
+	}
+
+	sorted := getSortedRows(nil, []SortColumn{{ColumnKey: "name", Direction: SortDirectionAsc}}, sortOptions{}, rows)
+
+	assert.Equal(t, "a", sorted[0].Data["name"])
+	assert.Equal(t, "b", rows[0].Data["name"])
+}
+
+func TestSortFunc(t *testing.T) {
+	severities := map[interface{}]int{
+		"low":      1,
+		"medium":   2,
+		"high":     3,
+		"critical": 4,
+	}
+
+	bySeverity := func(a, b interface{}) int {
+		return severities[a] - severities[b]
+	}
+
+	model := New([]Column{
+		NewColumn("name", "Name", 8),
+		NewColumn("severity", "Severity", 8).WithSortFunc(bySeverity),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "a", "severity": "medium"}),
+		NewRow(RowData{"name": "b", "severity": NewStyledCell("critical", lipgloss.NewStyle())}),
+		NewRow(RowData{"name": "c", "severity": "low"}),
+		NewRow(RowData{"name": "d"}),
+		NewRow(RowData{"name": "e", "severity": "high"}),
+	})
+
+	getNames := func() []string {
+		names := []string{}
+
+		for _, row := range model.GetVisibleRows() {
+			names = append(names, row.Data["name"].(string))
+		}
+
+		return names
+	}
+
+	model = model.SortByAsc("severity")
+
+	// Missing data is nil, which isn't in the map so it's first
+	assert.Equal(t, []string{"d", "c", "a", "e", "b"}, getNames())
+
+	model = model.SortByDesc("severity")
+
+	assert.Equal(t, []string{"b", "e", "a", "c", "d"}, getNames())
+
+	// Other columns still sort the default way
+	model = model.SortByDesc("name")
+
+	assert.Equal(t, []string{"e", "d", "c", "b", "a"}, getNames())
+}
+
+func TestSortFuncTieBreaksWithOtherColumns(t *testing.T) {
+	byLength := func(a, b interface{}) int {
+		return len(a.(string)) - len(b.(string))
+	}
+
+	model := New([]Column{
+		NewColumn("word", "Word", 8).WithSortFunc(byLength),
+		NewColumn("id", "ID", 3),
+	}).WithRows([]Row{
+		NewRow(RowData{"word": "ccc", "id": 1}),
+		NewRow(RowData{"word": "a", "id": 3}),
+		NewRow(RowData{"word": "bb", "id": 2}),
+		NewRow(RowData{"word": "dd", "id": 1}),
+	}).SortByAsc("word").ThenSortByDesc("id")
+
+	words := []string{}
+
+	for _, row := range model.GetVisibleRows() {
+		words = append(words, row.Data["word"].(string))
+	}
+
+	assert.Equal(t, []string{"a", "bb", "dd", "ccc"}, words)
+}
+
+func TestCompareNatural(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"item2", "item10", -1},
+		{"item10", "item2", 1},
+		{"item10", "item10", 0},
+		{"a1b2", "a1b10", -1},
+		{"1.9", "1.10", -1},
+		{"item02", "item2", 1},
+		{"item", "item1", -1},
+		{"b", "a10", 1},
+		{"10", "9a", 1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s vs %s", test.a, test.b), func(t *testing.T) {
+			compared := compareNatural(test.a, test.b)
+
+			switch {
+			case test.expected < 0:
+				assert.Less(t, compared, 0)
+
+			case test.expected > 0:
+				assert.Greater(t, compared, 0)
+
+			default:
+				assert.Equal(t, 0, compared)
+			}
+		})
+	}
+}
+
+func sortOptionsTestModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 8),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "item10"}),
+		NewRow(RowData{"name": "Zebra"}),
+		NewRow(RowData{}),
+		NewRow(RowData{"name": "item2"}),
+		NewRow(RowData{"name": "apple"}),
+	})
+}
+
+func sortedNames(model Model) []string {
+	names := []string{}
+
+	for _, row := range model.GetVisibleRows() {
+		name, exists := row.Data["name"]
+
+		if !exists {
+			name = "<missing>"
+		}
+
+		names = append(names, name.(string))
+	}
+
+	return names
+}
+
+func TestSortOptions(t *testing.T) {
+	model := sortOptionsTestModel().SortByAsc("name")
+
+	assert.Equal(t, []string{"<missing>", "Zebra", "apple", "item10", "item2"}, sortedNames(model))
+
+	model = model.WithNaturalSort(true)
+
+	assert.Equal(t, []string{"<missing>", "Zebra", "apple", "item2", "item10"}, sortedNames(model))
+
+	model = model.WithCaseInsensitiveSort(true)
+
+	assert.Equal(t, []string{"<missing>", "apple", "item2", "item10", "Zebra"}, sortedNames(model))
+
+	model = model.WithMissingSort(MissingSortLast)
+
+	assert.Equal(t, []string{"apple", "item2", "item10", "Zebra", "<missing>"}, sortedNames(model))
+
+	// Missing data stays last in either direction
+	model = model.SortByDesc("name")
+
+	assert.Equal(t, []string{"Zebra", "item10", "item2", "apple", "<missing>"}, sortedNames(model))
+
+	model = model.WithMissingSort(MissingSortFirst)
+
+	assert.Equal(t, []string{"<missing>", "Zebra", "item10", "item2", "apple"}, sortedNames(model))
+}
+
+func TestMissingSortWithSortFunc(t *testing.T) {
+	calledWithNil := false
+
+	byLength := func(a, b interface{}) int {
+		if a == nil || b == nil {
+			calledWithNil = true
+
+			return 0
+		}
+
+		return len(a.(string)) - len(b.(string))
+	}
+
+	model := New([]Column{
+		NewColumn("name", "Name", 8).WithSortFunc(byLength),
+	}).WithRows(sortOptionsTestModel().rows).
+		WithMissingSort(MissingSortLast).
+		SortByAsc("name")
+
+	assert.Equal(t, "<missing>", sortedNames(model)[4])
+	assert.False(t, calledWithNil, "Should not compare missing data")
+}
+
+func TestSortTimesAndPointers(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	one, ten := 1, 10
+
+	model := New([]Column{
+		NewColumn("name", "Name", 8),
+		NewColumn("at", "At", 8),
+		NewColumn("count", "Count", 8),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "b", "at": start.Add(time.Hour), "count": &ten}),
+		NewRow(RowData{"name": "c", "at": start.Add(time.Minute), "count": (*int)(nil)}),
+		NewRow(RowData{"name": "a", "at": start.Add(2 * time.Hour).In(time.FixedZone("HST", -10*60*60)), "count": &one}),
+	}).WithMissingSort(MissingSortLast)
+
+	// Sorting by the formatted string would put the earlier time zone first
+	assert.Equal(t, []string{"c", "b", "a"}, sortedNames(model.SortByAsc("at")))
+
+	// Nil pointers are missing, and sorting by the pointer address is random
+	assert.Equal(t, []string{"a", "b", "c"}, sortedNames(model.SortByAsc("count")))
+	assert.Equal(t, []string{"b", "a", "c"}, sortedNames(model.SortByDesc("count")))
+}
+
+func TestInteractiveSortToggle(t *testing.T) {
+	model := New([]Column{
+		NewColumn("a", "A", 3),
+		NewColumn("b", "B", 3),
+	}).Focused(true).WithCellCursor(true)
+
+	hitKey := func(key rune) {
+		model, _ = model.Update(tea.KeyMsg{
+			Type:  tea.KeyRunes,
+			Runes: []rune{key},
+		})
+	}
+
+	checkSortEvent := func(expected []SortColumn) {
+		t.Helper()
+
+		assert.Equal(t, []UserEvent{UserEventSortChanged{SortColumns: expected}}, model.GetLastUpdateUserEvents())
+		assert.Equal(t, expected, model.GetColumnSorting())
+	}
+
+	hitKey('s')
+	checkSortEvent([]SortColumn{{ColumnKey: "a", Direction: SortDirectionAsc}})
+
+	hitKey('s')
+	checkSortEvent([]SortColumn{{ColumnKey: "a", Direction: SortDirectionDesc}})
+
+	hitKey('s')
+	checkSortEvent([]SortColumn{})
+
+	hitKey('s')
+	hitKey('>')
+	assert.Equal(t, []SortColumn{{ColumnKey: "a", Direction: SortDirectionAsc}}, model.GetColumnSorting(), "Picking a column should not sort")
+
+	hitKey('s')
+	checkSortEvent([]SortColumn{{ColumnKey: "b", Direction: SortDirectionAsc}})
+
+	hitKey('>')
+	hitKey('<')
+	hitKey('s')
+	checkSortEvent([]SortColumn{{ColumnKey: "a", Direction: SortDirectionAsc}})
+}
+
+func TestInteractiveSortNeedsCellCursor(t *testing.T) {
+	model := New([]Column{
+		NewColumn("a", "A", 3),
+		NewColumn("b", "B", 3),
+	}).Focused(true)
+
+	// The highlighted column isn't shown, so there's nothing to sort by
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+
+	assert.Empty(t, model.GetColumnSorting())
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+	assert.False(t, model.isHeaderHighlighted(0))
+
+	model = model.WithCellCursor(true)
+
+	assert.True(t, model.isHeaderHighlighted(0), "Should show which column will be sorted")
+	assert.False(t, model.isHeaderHighlighted(1))
+}
+
+func TestInteractiveSortToggleMulti(t *testing.T) {
+	model := New([]Column{
+		NewColumn("a", "A", 3),
+		NewColumn("b", "B", 3),
+	}).Focused(true).WithCellCursor(true).SortByDesc("a")
+
+	hitKey := func(key rune) {
+		model, _ = model.Update(tea.KeyMsg{
+			Type:  tea.KeyRunes,
+			Runes: []rune{key},
+		})
+	}
+
+	hitKey('>')
+	hitKey('S')
+	assert.Equal(t, []SortColumn{
+		{ColumnKey: "b", Direction: SortDirectionAsc},
+		{ColumnKey: "a", Direction: SortDirectionDesc},
+	}, model.GetColumnSorting(), "New column should break ties of the existing sort")
+
+	hitKey('S')
+	assert.Equal(t, []SortColumn{
+		{ColumnKey: "b", Direction: SortDirectionDesc},
+		{ColumnKey: "a", Direction: SortDirectionDesc},
+	}, model.GetColumnSorting())
+
+	hitKey('S')
+	assert.Equal(t, []SortColumn{
+		{ColumnKey: "a", Direction: SortDirectionDesc},
+	}, model.GetColumnSorting())
+
+	hitKey('<')
+	hitKey('S')
+	assert.Equal(t, []SortColumn{}, model.GetColumnSorting())
+}
+
+func TestInteractiveSortSkipsUnsortable(t *testing.T) {
+	model := New([]Column{
+		NewColumn("a", "A", 3),
+		NewColumn("b", "B", 3).WithSortable(false),
+		NewColumn("c", "C", 3),
+	}).Focused(true).WithCellCursor(true).SelectableRows(true)
+
+	hitKey := func(key rune) {
+		model, _ = model.Update(tea.KeyMsg{
+			Type:  tea.KeyRunes,
+			Runes: []rune{key},
+		})
+	}
+
+	hitKey('>')
+	hitKey('s')
+	assert.Equal(t, []SortColumn{{ColumnKey: "c", Direction: SortDirectionAsc}}, model.GetColumnSorting())
+
+	hitKey('<')
+	hitKey('s')
+	assert.Equal(t, []SortColumn{{ColumnKey: "a", Direction: SortDirectionAsc}}, model.GetColumnSorting())
+
+	hitKey('<')
+	hitKey('s')
+	assert.Equal(t, []SortColumn{{ColumnKey: "a", Direction: SortDirectionDesc}}, model.GetColumnSorting(), "Should not move to the select column")
+
+	model = model.WithHighlightedColumn("b")
+	hitKey('s')
+	assert.Empty(t, model.GetLastUpdateUserEvents(), "Unsortable column should not sort")
+}
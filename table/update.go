@@ -9,7 +9,7 @@ func (m *Model) moveHighlightUp() {
 	m.rowCursorIndex--
 
 	if m.rowCursorIndex < 0 {
-		m.rowCursorIndex = len(m.GetVisibleRows()) - 1
+		m.rowCursorIndex = m.visibleRowCount() - 1
 	}
 
 	m.currentPage = m.expectedPageForRowIndex(m.rowCursorIndex)
@@ -18,31 +18,83 @@ func (m *Model) moveHighlightUp() {
 func (m *Model) moveHighlightDown() {
 	m.rowCursorIndex++
 
-	if m.rowCursorIndex >= len(m.GetVisibleRows()) {
+	if m.rowCursorIndex >= m.visibleRowCount() {
 		m.rowCursorIndex = 0
 	}
 
 	m.currentPage = m.expectedPageForRowIndex(m.rowCursorIndex)
 }
 
-func (m *Model) toggleSelect() {
-	if !m.selectableRows || len(m.GetVisibleRows()) == 0 {
+func (m *Model) moveHighlightLeft() {
+	if !m.cellCursor {
+		return
+	}
+
+	if m.columnCursorIndex > m.firstHighlightableColumnIndex() {
+		m.columnCursorIndex--
+	}
+
+	m.scrollToHighlightedColumn()
+}
+
+func (m *Model) moveHighlightRight() {
+	if !m.cellCursor {
 		return
 	}
 
-	rows := make([]Row, len(m.GetVisibleRows()))
-	copy(rows, m.GetVisibleRows())
+	if m.columnCursorIndex < len(m.columns)-1 {
+		m.columnCursorIndex++
+	}
+
+	m.scrollToHighlightedColumn()
+}
 
-	currentSelectedState := rows[m.rowCursorIndex].selected
+// firstHighlightableColumnIndex skips over the select column, since it's not
+// a real data cell.
+func (m *Model) firstHighlightableColumnIndex() int {
+	if m.selectableRows {
+		return 1
+	}
 
-	rows[m.rowCursorIndex].selected = !currentSelectedState
+	return 0
+}
 
-	m.rows = rows
-	m.visibleRowCacheUpdated = false
+func (m *Model) clampColumnCursor() {
+	if m.columnCursorIndex >= len(m.columns) {
+		m.columnCursorIndex = len(m.columns) - 1
+	}
+
+	if m.columnCursorIndex < m.firstHighlightableColumnIndex() {
+		m.columnCursorIndex = m.firstHighlightableColumnIndex()
+	}
+}
+
+func (m *Model) columnKeyAtIndex(columnIndex int) string {
+	if columnIndex < 0 || columnIndex >= len(m.columns) {
+		return ""
+	}
+
+	return m.columns[columnIndex].key
+}
+
+func (m *Model) toggleSelect() {
+	if !m.selectableRows || m.visibleRowCount() == 0 {
+		return
+	}
+
+	changed := m.setVisibleRowsSelected(m.rowCursorIndex, m.rowCursorIndex, selectInverted)
+
+	// Rows from a data source can't be selected
+	if len(changed.SelectedRowIndices) == 0 && len(changed.DeselectedRowIndices) == 0 {
+		return
+	}
+
+	m.selectionAnchorIndex = m.rowCursorIndex
+	m.selectionRangeEndIndex = -1
 
 	m.appendUserEvent(UserEventRowSelectToggled{
 		RowIndex:   m.rowCursorIndex,
-		IsSelected: !currentSelectedState,
+		IsSelected: len(changed.SelectedRowIndices) > 0,
 	})
 }
 
@@ -50,13 +102,29 @@ func (m Model) updateFilterTextInput(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if key.Matches(msg, m.keyMap.FilterModeToggle) {
+			m.cycleFilterMode()
+
+			return m, nil
+		}
+
 		if key.Matches(msg, m.keyMap.FilterBlur) {
 			m.filterTextInput.Blur()
 		}
 	}
+	previous := m.filterTextInput.Value()
+
 	m.filterTextInput, cmd = m.filterTextInput.Update(msg)
-	m.pageFirst()
-	m.visibleRowCacheUpdated = false
+
+	if m.filterDebounce > 0 {
+		if m.filterTextInput.Value() == previous {
+			return m, cmd
+		}
+
+		return m, batchCmds(cmd, m.debounceFilter(previous))
+	}
+
+	m.applyFilterText(previous)
 
 	return m, cmd
 }
@@ -66,6 +134,7 @@ func (m Model) updateFilterTextInput(msg tea.Msg) (Model, tea.Cmd) {
 //nolint:cyclop
 func (m *Model) handleKeypress(msg tea.KeyMsg) {
 	previousRowIndex := m.rowCursorIndex
+	previousColumnIndex := m.columnCursorIndex
 
 	if key.Matches(msg, m.keyMap.RowDown) {
 		m.moveHighlightDown()
@@ -75,10 +144,42 @@ func (m *Model) handleKeypress(msg tea.KeyMsg) {
 		m.moveHighlightUp()
 	}
 
+	if key.Matches(msg, m.keyMap.ColumnLeft) {
+		m.moveHighlightLeft()
+	}
+
+	if key.Matches(msg, m.keyMap.ColumnRight) {
+		m.moveHighlightRight()
+	}
+
 	if key.Matches(msg, m.keyMap.RowSelectToggle) {
 		m.toggleSelect()
 	}
 
+	if key.Matches(msg, m.keyMap.RowSelectRangeDown) {
+		m.userSelectRange(m.moveHighlightDownNoWrap)
+	}
+
+	if key.Matches(msg, m.keyMap.RowSelectRangeUp) {
+		m.userSelectRange(m.moveHighlightUpNoWrap)
+	}
+
+	if key.Matches(msg, m.keyMap.RowSelectAll) {
+		m.userSelectAll()
+	}
+
+	if key.Matches(msg, m.keyMap.RowSelectNone) {
+		m.userSelectNone()
+	}
+
+	if key.Matches(msg, m.keyMap.RowSelectInvert) {
+		m.userSelectInvert()
+	}
+
+	if key.Matches(msg, m.keyMap.RowSelectPage) {
+		m.userSelectPage()
+	}
+
 	if key.Matches(msg, m.keyMap.PageDown) {
 		m.pageDown()
 	}
@@ -93,16 +194,45 @@ func (m *Model) handleKeypress(msg tea.KeyMsg) {
 
 	if key.Matches(msg, m.keyMap.PageLast) {
 		m.pageLast()
+		m.startFollowingFromUser()
 	}
 
 	if key.Matches(msg, m.keyMap.Filter) {
-		m.filterTextInput.Focus()
+		if !m.isColumnFilterRowVisible() || !m.startColumnFilterTyping() {
+			m.filterTextInput.Focus()
+		}
+
 		m.appendUserEvent(UserEventFilterInputFocused{})
 	}
 
+	if key.Matches(msg, m.keyMap.CellEdit) {
+		m.startCellEdit()
+	}
+
 	if key.Matches(msg, m.keyMap.FilterClear) {
-		m.visibleRowCacheUpdated = false
+		if m.filterText() != "" {
+			m.appendUserEvent(UserEventFilterChanged{})
+		}
+
+		m.invalidateFilterAndSort()
 		m.filterTextInput.Reset()
+		m.cancelFilterDebounce()
+		m.updateFilterRegex()
+		m.clearColumnFilters()
+		m.facetFilters = nil
+
+		if m.following {
+			m.pinToNewestRow()
+		}
+	}
+
+	if key.Matches(msg, m.keyMap.FilterModeToggle) {
+		m.cycleFilterMode()
+	}
+
+	// The highlighted column is only shown with the cell cursor
+	if key.Matches(msg, m.keyMap.FacetPickerOpen) && m.cellCursor {
+		m.openFacetPicker(m.columnKeyAtIndex(m.columnCursorIndex))
 	}
 
 	if key.Matches(msg, m.keyMap.ScrollRight) {
@@ -113,22 +243,101 @@ func (m *Model) handleKeypress(msg tea.KeyMsg) {
 		m.scrollLeft()
 	}
 
+	if key.Matches(msg, m.keyMap.SortColumnNext) {
+		m.moveSortColumn(1)
+	}
+
+	if key.Matches(msg, m.keyMap.SortColumnPrevious) {
+		m.moveSortColumn(-1)
+	}
+
+	if key.Matches(msg, m.keyMap.SortToggle) {
+		m.toggleSortHighlightedColumn(false)
+	}
+
+	if key.Matches(msg, m.keyMap.SortToggleMulti) {
+		m.toggleSortHighlightedColumn(true)
+	}
+
+	m.appendHighlightChangedEvents(previousRowIndex, previousColumnIndex)
+}
+
+func (m *Model) appendHighlightChangedEvents(previousRowIndex, previousColumnIndex int) {
 	if m.rowCursorIndex != previousRowIndex {
 		m.appendUserEvent(UserEventHighlightedIndexChanged{
 			PreviousRowIndex: previousRowIndex,
 			SelectedRowIndex: m.rowCursorIndex,
 		})
 	}
+
+	if !m.cellCursor {
+		return
+	}
+
+	if m.rowCursorIndex != previousRowIndex || m.columnCursorIndex != previousColumnIndex {
+		m.appendUserEvent(UserEventHighlightedCellChanged{
+			PreviousRowIndex:  previousRowIndex,
+			PreviousColumnKey: m.columnKeyAtIndex(previousColumnIndex),
+			RowIndex:          m.rowCursorIndex,
+			ColumnKey:         m.columnKeyAtIndex(m.columnCursorIndex),
+		})
+	}
 }
 
 // Update responds to input from the user or other messages from Bubble Tea.
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(visibleRowsMsg); ok {
+		m.clearUserEvents()
+
+		return m.receiveVisibleRows(msg)
+	}
+
+	// Keep showing the current rows while handling the message, so that any
+	// filtering and sorting it causes can be done in the background
+	m.asyncInUpdate = m.asyncFilterSort
+
+	m, cmd := m.update(msg)
+
+	m.asyncInUpdate = false
+
+	return m, batchCmds(cmd, m.startAsyncFilterSort())
+}
+
+func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
 	m.clearUserEvents()
 
+	if msg, ok := msg.(filterDebounceMsg); ok {
+		return m.receiveFilterDebounce(msg), nil
+	}
+
 	if !m.focused {
 		return m, nil
 	}
 
+	if m.cellEditTextInput.Focused() {
+		var cmd tea.Cmd
+		m, cmd = m.updateCellEditTextInput(msg)
+
+		return m, cmd
+	}
+
+	if m.columnFilterFocused() {
+		var cmd tea.Cmd
+		m, cmd = m.updateColumnFilterInput(msg)
+
+		if !m.columnFilterFocused() {
+			m.appendUserEvent(UserEventFilterInputUnfocused{})
+		}
+
+		if m.following {
+			m.pinToNewestRow()
+		}
+
+		m.updateVerticalScroll()
+
+		return m, cmd
+	}
+
 	if m.filterTextInput.Focused() {
 		var cmd tea.Cmd
 		m, cmd = m.updateFilterTextInput(msg)
@@ -137,13 +346,37 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			m.appendUserEvent(UserEventFilterInputUnfocused{})
 		}
 
+		if m.following {
+			m.pinToNewestRow()
+		}
+
+		m.updateVerticalScroll()
+
 		return m, cmd
 	}
 
+	if m.isFacetPickerOpen() {
+		m = m.updateFacetPicker(msg)
+
+		if m.following {
+			m.pinToNewestRow()
+		}
+
+		m.updateVerticalScroll()
+
+		return m, nil
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		m.handleKeypress(msg)
+
+	case tea.MouseMsg:
+		m.handleMouse(msg)
 	}
 
+	m.stopFollowingIfMoved()
+	m.updateVerticalScroll()
+
 	return m, nil
 }
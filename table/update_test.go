@@ -330,3 +330,99 @@ func TestFilterWithKeypresses(t *testing.T) {
 
 	assert.Len(t, visible, 2)
 }
+
+func TestCellCursorMovesBetweenColumns(t *testing.T) {
+	model := New([]Column{
+		NewColumn("a", "A", 3),
+		NewColumn("b", "B", 3),
+		NewColumn("c", "C", 3),
+	}).WithRows([]Row{
+		NewRow(RowData{"a": 1, "b": 2, "c": 3}),
+	}).Focused(true)
+
+	keyLeft := tea.KeyMsg{Type: tea.KeyCtrlLeft}
+	keyRight := tea.KeyMsg{Type: tea.KeyCtrlRight}
+
+	model, _ = model.Update(keyRight)
+	assert.Equal(t, "", model.GetHighlightedColumnKey(), "Should not move without cell cursor enabled")
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+
+	model = model.WithCellCursor(true)
+	assert.Equal(t, "a", model.GetHighlightedColumnKey())
+
+	model, _ = model.Update(keyLeft)
+	assert.Equal(t, "a", model.GetHighlightedColumnKey(), "Should not wrap to the left")
+
+	model, _ = model.Update(keyRight)
+	assert.Equal(t, "b", model.GetHighlightedColumnKey())
+	assert.Equal(t, 2, model.HighlightedCell())
+
+	model, _ = model.Update(keyRight)
+	model, _ = model.Update(keyRight)
+	assert.Equal(t, "c", model.GetHighlightedColumnKey(), "Should not wrap to the right")
+
+	model, _ = model.Update(keyLeft)
+	assert.Equal(t, "b", model.GetHighlightedColumnKey())
+}
+
+func TestCellCursorSkipsSelectColumn(t *testing.T) {
+	model := New([]Column{
+		NewColumn("a", "A", 3),
+		NewColumn("b", "B", 3),
+	}).WithRows([]Row{
+		NewRow(RowData{"a": 1, "b": 2}),
+	}).Focused(true).WithCellCursor(true).WithHighlightedColumn("b")
+
+	model = model.SelectableRows(true)
+	assert.Equal(t, "b", model.GetHighlightedColumnKey(), "Should stay on the same column when select column is added")
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlLeft})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlLeft})
+	assert.Equal(t, "a", model.GetHighlightedColumnKey(), "Should not move onto the select column")
+
+	model = model.WithColumns([]Column{
+		NewColumn("a", "A", 3),
+		NewColumn("b", "B", 3),
+	})
+	assert.Equal(t, "a", model.GetHighlightedColumnKey(), "Should stay on the same column when columns are refreshed")
+
+	model = model.SelectableRows(false)
+	assert.Equal(t, "a", model.GetHighlightedColumnKey(), "Should stay on the same column when select column is removed")
+}
+
+func TestCellCursorScrollsHorizontally(t *testing.T) {
+	model := New([]Column{
+		NewColumn("1", "1", 4),
+		NewColumn("2", "2", 4),
+		NewColumn("3", "3", 4),
+		NewColumn("4", "4", 4),
+	}).
+		WithRows([]Row{NewRow(RowData{})}).
+		WithMaxTotalWidth(18).
+		WithHorizontalFreezeColumnCount(1).
+		WithCellCursor(true).
+		Focused(true)
+
+	keyLeft := tea.KeyMsg{Type: tea.KeyCtrlLeft}
+	keyRight := tea.KeyMsg{Type: tea.KeyCtrlRight}
+
+	model, _ = model.Update(keyRight)
+	model, _ = model.Update(keyRight)
+	assert.Equal(t, "3", model.GetHighlightedColumnKey())
+	assert.Equal(t, 0, model.GetHorizontalScrollColumnOffset(), "Column 3 is already visible")
+
+	model, _ = model.Update(keyRight)
+	assert.Equal(t, "4", model.GetHighlightedColumnKey())
+	assert.Equal(t, 1, model.GetHorizontalScrollColumnOffset(), "Should scroll to show column 4")
+
+	model, _ = model.Update(keyLeft)
+	assert.Equal(t, 1, model.GetHorizontalScrollColumnOffset(), "Column 3 is still visible")
+
+	model, _ = model.Update(keyLeft)
+	assert.Equal(t, "2", model.GetHighlightedColumnKey())
+	assert.Equal(t, 0, model.GetHorizontalScrollColumnOffset(), "Should scroll back to show column 2")
+
+	model, _ = model.Update(keyLeft)
+	assert.Equal(t, "1", model.GetHighlightedColumnKey())
+	assert.Equal(t, 0, model.GetHorizontalScrollColumnOffset(), "Frozen column is always visible")
+}
@@ -35,12 +35,25 @@ func (m Model) View() string {
 		rowStrs = append(rowStrs, split[0])
 	}
 
-	for i := startRowIndex; i <= endRowIndex; i++ {
-		rowStrs = append(rowStrs, m.renderRow(i, padding == 0 && i == endRowIndex))
+	headerLines := 0
+	if len(rowStrs) > 0 {
+		headerLines = lipgloss.Height(rowStrs[0])
+	}
+
+	rowLines := 0
+
+	// Only the rows in view are fetched, which matters for data sources
+	for i, row := range m.visibleRowsBetween(startRowIndex, endRowIndex) {
+		rowIndex := startRowIndex + i
+		rendered := m.renderRow(row, rowIndex, padding == 0 && rowIndex == endRowIndex)
+		rowLines += lipgloss.Height(rendered)
+		rowStrs = append(rowStrs, rendered)
 	}
 
 	for i := 1; i <= padding; i++ {
-		rowStrs = append(rowStrs, m.renderBlankRow(i == padding))
+		rendered := m.renderBlankRow(i == padding)
+		rowLines += lipgloss.Height(rendered)
+		rowStrs = append(rowStrs, rendered)
 	}
 
 	var footer string
@@ -59,7 +72,14 @@ func (m Model) View() string {
 		return ""
 	}
 
-	body.WriteString(lipgloss.JoinVertical(lipgloss.Left, rowStrs...))
+	rendered := lipgloss.JoinVertical(lipgloss.Left, rowStrs...)
+
+	if m.scrollbarWidth() > 0 && rowLines > 0 {
+		// The last row line is the bottom border
+		rendered = m.renderScrollbar(rendered, headerLines, rowLines-1)
+	}
+
+	body.WriteString(rendered)
 
 	return body.String()
 }
@@ -112,7 +112,7 @@ func TestSingleColumnViewSorted(t *testing.T) {
 	}).SortByDesc("id")
 
 	const expectedTable = `┏━━━━┓
-┃  ID┃
+┃ID ▼┃
 ┣━━━━┫
 ┃   2┃
 ┃   1┃
@@ -1292,7 +1292,7 @@ func TestSingleColumnViewSortedAndFormatted(t *testing.T) {
 	}).SortByAsc("val")
 
 	const expectedTable = `┏━━━━━┳━━━━━━━┓
-┃ Name┃  Value┃
+┃ Name┃Value ▲┃
 ┣━━━━━╋━━━━━━━┫
 ┃    Φ┃  ~1.62┃
 ┃    π┃  ~3.14┃
@@ -1588,3 +1588,67 @@ func TestMultilineDisabledExplicite(t *testing.T) {
 	rendered := model.View()
 	assert.Equal(t, expectedTable, rendered)
 }
+
+func TestCellCursorHighlightStyleAppliesToSingleCell(t *testing.T) {
+	model := New([]Column{
+		NewColumn("1", "1", 4),
+		NewColumn("2", "2", 4),
+	}).WithRows([]Row{
+		NewRow(RowData{"1": "a", "2": "b"}),
+		NewRow(RowData{"1": "c", "2": "d"}),
+	}).
+		// Test with alignment because it's easy to check output string
+		HighlightStyle(lipgloss.NewStyle()).
+		HighlightCellStyle(lipgloss.NewStyle().Align(lipgloss.Left)).
+		WithCellCursor(true).
+		WithHighlightedColumn("2").
+		Focused(true)
+
+	const expectedTable = `┏━━━━┳━━━━┓
+┃   1┃   2┃
+┣━━━━╋━━━━┫
+┃   a┃b   ┃
+┃   c┃   d┃
+┗━━━━┻━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+
+	const expectedUnfocusedTable = `┏━━━━┳━━━━┓
+┃   1┃   2┃
+┣━━━━╋━━━━┫
+┃   a┃   b┃
+┃   c┃   d┃
+┗━━━━┻━━━━┛`
+
+	assert.Equal(t, expectedUnfocusedTable, model.Focused(false).View())
+}
+
+func TestSortIndicators(t *testing.T) {
+	model := New([]Column{
+		NewColumn("name", "Name", 6),
+		NewColumn("type", "Type", 5),
+		NewColumn("id", "LongerID", 5),
+	}).SortByAsc("id").ThenSortByDesc("name")
+
+	const expectedTable = `┏━━━━━━┳━━━━━┳━━━━━┓
+┃Name▼2┃ Type┃Lo…▲1┃
+┗━━━━━━┻━━━━━┻━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+
+	model = model.SortByDesc("type").WithSortIndicators("^", "v")
+
+	const expectedCustomTable = `┏━━━━━━┳━━━━━┳━━━━━┓
+┃  Name┃Typev┃Long…┃
+┗━━━━━━┻━━━━━┻━━━━━┛`
+
+	assert.Equal(t, expectedCustomTable, model.View())
+
+	model = model.WithSortIndicators("", "")
+
+	const expectedHiddenTable = `┏━━━━━━┳━━━━━┳━━━━━┓
+┃  Name┃ Type┃Long…┃
+┗━━━━━━┻━━━━━┻━━━━━┛`
+
+	assert.Equal(t, expectedHiddenTable, model.View())
+}
@@ -0,0 +1,198 @@
+package table
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	scrollbarThumb = "┃"
+	scrollbarTrack = "│"
+)
+
+// isViewportScrolling returns true if the table should slide a window of rows
+// to follow the cursor rather than use pagination.
+func (m *Model) isViewportScrolling() bool {
+	return m.maxHeight > 0 && m.pageSize == 0
+}
+
+// viewportRowLineBudget returns how many lines are available to draw rows in
+// when the table is limited by a maximum height.
+func (m *Model) viewportRowLineBudget() int {
+	// Additional 1 for bottom border
+	return max(m.maxHeight-m.metaHeight-1, 1)
+}
+
+func (m *Model) viewportRowHeight(rowIndex int) int {
+	if !m.multiline {
+		return 1
+	}
+
+	row := m.visibleRowAt(rowIndex)
+
+	return m.rowContentHeight(row, row.Style)
+}
+
+// viewportEndIndex returns the last row index that fits in the viewport if
+// the given row index is the first visible row.  At least one row is always
+// considered visible even if it doesn't fit.
+func (m *Model) viewportEndIndex(startIndex int) int {
+	budget := m.viewportRowLineBudget()
+	totalRows := m.visibleRowCount()
+
+	if !m.multiline {
+		return min(startIndex+budget, totalRows) - 1
+	}
+
+	usedLines := 0
+
+	for i := startIndex; i < totalRows; i++ {
+		usedLines += m.viewportRowHeight(i)
+
+		if usedLines > budget && i > startIndex {
+			return i - 1
+		}
+	}
+
+	return totalRows - 1
+}
+
+// viewportRowsFit returns true if all rows between the given indices,
+// inclusive, fit in the viewport together.
+func (m *Model) viewportRowsFit(startIndex, endIndex int) bool {
+	budget := m.viewportRowLineBudget()
+
+	if !m.multiline {
+		return endIndex-startIndex+1 <= budget
+	}
+
+	total := 0
+
+	for i := startIndex; i <= endIndex; i++ {
+		total += m.viewportRowHeight(i)
+
+		// Stop early to avoid measuring rows that can't be shown anyway
+		if total > budget {
+			return false
+		}
+	}
+
+	return true
+}
+
+// viewportStartIndex calculates where the viewport should start so that the
+// cursor is visible, moving as little as possible from the given previous
+// start so that the viewport slides smoothly one row at a time.
+func (m *Model) viewportStartIndex(previousStartIndex int) int {
+	totalRows := m.visibleRowCount()
+
+	if totalRows == 0 {
+		return 0
+	}
+
+	cursor := min(max(m.rowCursorIndex, 0), totalRows-1)
+	start := min(max(previousStartIndex, 0), totalRows-1)
+
+	// Keep the margin above the cursor in view
+	if cursor-m.verticalScrollMargin < start {
+		start = max(cursor-m.verticalScrollMargin, 0)
+	}
+
+	// Keep the margin below the cursor in view, but never scroll the cursor
+	// itself out of the top
+	wantedEnd := min(cursor+m.verticalScrollMargin, totalRows-1)
+
+	for start < cursor && !m.viewportRowsFit(start, wantedEnd) {
+		start++
+	}
+
+	// Don't leave empty space at the bottom if there are rows above to fill it
+	for start > 0 && m.viewportRowsFit(start-1, totalRows-1) {
+		start--
+	}
+
+	return start
+}
+
+// updateVerticalScroll remembers the current viewport position, so that the
+// next movement slides from there.
+func (m *Model) updateVerticalScroll() {
+	if !m.isViewportScrolling() {
+		m.verticalScrollOffsetRow = 0
+
+		return
+	}
+
+	m.verticalScrollOffsetRow = m.viewportStartIndex(m.verticalScrollOffsetRow)
+}
+
+func (m *Model) viewportPageDown() {
+	start, end := m.VisibleIndices()
+	totalRows := m.visibleRowCount()
+
+	m.rowCursorIndex = min(m.rowCursorIndex+end-start+1, totalRows-1)
+	m.rowCursorIndex = max(m.rowCursorIndex, 0)
+	m.updateVerticalScroll()
+}
+
+func (m *Model) viewportPageUp() {
+	start, end := m.VisibleIndices()
+
+	m.rowCursorIndex = max(m.rowCursorIndex-(end-start+1), 0)
+	m.updateVerticalScroll()
+}
+
+// scrollbarWidth returns how many columns the scrollbar takes up to the right
+// of the table.
+func (m *Model) scrollbarWidth() int {
+	if m.scrollbarVisible && m.isViewportScrolling() {
+		return 1
+	}
+
+	return 0
+}
+
+// renderScrollbar adds a scrollbar gutter to the right side of the rendered
+// table.  The track runs alongside the row lines, skipping the header above and
+// the bottom border and footer below.
+func (m Model) renderScrollbar(body string, headerLines, trackLines int) string {
+	lines := strings.Split(body, "\n")
+
+	startRowIndex, endRowIndex := m.VisibleIndices()
+	totalRows := m.visibleRowCount()
+	visibleRows := endRowIndex - startRowIndex + 1
+
+	thumbStart, thumbEnd := 0, 0
+
+	if totalRows > visibleRows && trackLines > 0 {
+		thumbSize := max(trackLines*visibleRows/totalRows, 1)
+		thumbStart = trackLines * startRowIndex / totalRows
+
+		if endRowIndex == totalRows-1 {
+			thumbStart = trackLines - thumbSize
+		}
+
+		thumbStart = min(thumbStart, trackLines-thumbSize)
+		thumbEnd = thumbStart + thumbSize
+	}
+
+	style := lipgloss.NewStyle().Foreground(m.baseStyle.GetBorderRightForeground())
+
+	for i := range lines {
+		trackIndex := i - headerLines
+
+		switch {
+		case trackIndex < 0 || trackIndex >= trackLines:
+			lines[i] += " "
+
+		case trackIndex >= thumbStart && trackIndex < thumbEnd:
+			lines[i] += style.Render(scrollbarThumb)
+
+		default:
+			lines[i] += style.Render(scrollbarTrack)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,266 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func viewportTestModel(numRows int) Model {
+	rows := []Row{}
+
+	for i := 0; i < numRows; i++ {
+		rows = append(rows, NewRow(RowData{"id": i}))
+	}
+
+	return New([]Column{
+		NewColumn("id", "ID", 3),
+	}).WithRows(rows).Focused(true)
+}
+
+func TestViewportScrollsOneRowAtATime(t *testing.T) {
+	// Header is 3 lines and the bottom border is 1, which leaves 4 rows
+	model := viewportTestModel(10).WithMaxHeight(8)
+
+	keyDown := tea.KeyMsg{Type: tea.KeyDown}
+	keyUp := tea.KeyMsg{Type: tea.KeyUp}
+
+	checkIndices := func(expectedStart, expectedEnd int) {
+		t.Helper()
+
+		start, end := model.VisibleIndices()
+
+		assert.Equal(t, expectedStart, start, "Wrong start index")
+		assert.Equal(t, expectedEnd, end, "Wrong end index")
+	}
+
+	checkIndices(0, 3)
+
+	for i := 0; i < 3; i++ {
+		model, _ = model.Update(keyDown)
+	}
+
+	checkIndices(0, 3)
+
+	model, _ = model.Update(keyDown)
+	checkIndices(1, 4)
+
+	model, _ = model.Update(keyDown)
+	checkIndices(2, 5)
+
+	// Moving back up shouldn't scroll until reaching the top
+	model, _ = model.Update(keyUp)
+	model, _ = model.Update(keyUp)
+	model, _ = model.Update(keyUp)
+	checkIndices(2, 5)
+
+	model, _ = model.Update(keyUp)
+	checkIndices(1, 4)
+
+	// Wrapping around to the bottom
+	model, _ = model.Update(keyUp)
+	model, _ = model.Update(keyUp)
+	checkIndices(6, 9)
+	assert.Equal(t, 9, model.GetHighlightedRowIndex())
+	assert.Equal(t, 6, model.GetVerticalScrollRowOffset())
+}
+
+func TestViewportScrollMargin(t *testing.T) {
+	model := viewportTestModel(10).WithMaxHeight(8).WithScrollMargin(1)
+
+	keyDown := tea.KeyMsg{Type: tea.KeyDown}
+	keyUp := tea.KeyMsg{Type: tea.KeyUp}
+
+	model, _ = model.Update(keyDown)
+	model, _ = model.Update(keyDown)
+
+	start, end := model.VisibleIndices()
+	assert.Equal(t, 0, start)
+	assert.Equal(t, 3, end)
+
+	model, _ = model.Update(keyDown)
+
+	start, end = model.VisibleIndices()
+	assert.Equal(t, 1, start, "Should keep one row below the cursor visible")
+	assert.Equal(t, 4, end)
+
+	model, _ = model.Update(keyUp)
+
+	start, _ = model.VisibleIndices()
+	assert.Equal(t, 1, start, "Should keep one row above the cursor visible")
+
+	model, _ = model.Update(keyUp)
+
+	start, _ = model.VisibleIndices()
+	assert.Equal(t, 0, start, "Should keep one row above the cursor visible")
+}
+
+func TestViewportPageKeys(t *testing.T) {
+	model := viewportTestModel(10).WithMaxHeight(8)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	assert.Equal(t, 4, model.GetHighlightedRowIndex())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	assert.Equal(t, 9, model.GetHighlightedRowIndex())
+
+	start, end := model.VisibleIndices()
+	assert.Equal(t, 6, start)
+	assert.Equal(t, 9, end)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	assert.Equal(t, 5, model.GetHighlightedRowIndex())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyHome})
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+
+	start, _ = model.VisibleIndices()
+	assert.Equal(t, 0, start)
+}
+
+func TestViewportIgnoredWhenPaginated(t *testing.T) {
+	model := viewportTestModel(10).WithMaxHeight(8).WithPageSize(3)
+
+	start, end := model.VisibleIndices()
+
+	assert.Equal(t, 0, start)
+	assert.Equal(t, 2, end)
+}
+
+func TestViewportFillsSpaceWhenRowsRemoved(t *testing.T) {
+	model := viewportTestModel(10).WithMaxHeight(8).WithHighlightedRow(9)
+
+	start, _ := model.VisibleIndices()
+	assert.Equal(t, 6, start)
+
+	model = model.WithRows(viewportTestModel(5).rows)
+
+	start, end := model.VisibleIndices()
+	assert.Equal(t, 1, start)
+	assert.Equal(t, 4, end)
+}
+
+func TestViewportView(t *testing.T) {
+	model := viewportTestModel(10).WithMaxHeight(8).WithHighlightedRow(5)
+
+	const expectedTable = `┏━━━┓
+┃ ID┃
+┣━━━┫
+┃  2┃
+┃  3┃
+┃  4┃
+┃  5┃
+┗━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+}
+
+func TestViewportViewWithScrollbar(t *testing.T) {
+	model := viewportTestModel(8).
+		WithColumns([]Column{NewColumn("id", "ID", 6)}).
+		WithMaxHeight(8).
+		WithScrollbar(true).
+		WithStaticFooter("Footer")
+
+	// Footer takes up 2 lines, leaving 2 rows.  Lines without the scrollbar
+	// are padded with a space to keep the view rectangular.
+	expectedTop := strings.Join([]string{
+		"┏━━━━━━┓ ",
+		"┃    ID┃ ",
+		"┣━━━━━━┫ ",
+		"┃     0┃┃",
+		"┃     1┃│",
+		"┣━━━━━━┫ ",
+		"┃Footer┃ ",
+		"┗━━━━━━┛ ",
+	}, "\n")
+
+	assert.Equal(t, expectedTop, model.View())
+
+	model = model.WithHighlightedRow(7)
+
+	expectedBottom := strings.Join([]string{
+		"┏━━━━━━┓ ",
+		"┃    ID┃ ",
+		"┣━━━━━━┫ ",
+		"┃     6┃│",
+		"┃     7┃┃",
+		"┣━━━━━━┫ ",
+		"┃Footer┃ ",
+		"┗━━━━━━┛ ",
+	}, "\n")
+
+	assert.Equal(t, expectedBottom, model.View())
+}
+
+func TestViewportScrollbarFitsInWidth(t *testing.T) {
+	model := New([]Column{
+		NewFlexColumn("name", "Name", 1),
+		NewColumn("id", "ID", 3),
+	}).WithRows(viewportTestModel(20).rows).
+		WithTargetWidth(20).
+		WithMaxHeight(10).
+		WithScrollbar(true)
+
+	for _, line := range strings.Split(model.View(), "\n") {
+		assert.Equal(t, 20, lipgloss.Width(line), "Wrong width for %q", line)
+	}
+
+	model = model.WithScrollbar(false)
+
+	for _, line := range strings.Split(model.View(), "\n") {
+		assert.Equal(t, 20, lipgloss.Width(line), "Wrong width for %q", line)
+	}
+
+	model = New([]Column{
+		NewColumn("id", "ID", 3),
+		NewColumn("name", "Name", 8),
+	}).WithRows(viewportTestModel(20).rows).
+		WithMaxTotalWidth(12).
+		WithMaxHeight(10).
+		WithScrollbar(true)
+
+	for _, line := range strings.Split(model.View(), "\n") {
+		assert.LessOrEqual(t, lipgloss.Width(line), 12, "Too wide: %q", line)
+	}
+}
+
+func TestViewportScrollbarHiddenWhenAllRowsFit(t *testing.T) {
+	model := viewportTestModel(2).WithMaxHeight(8).WithScrollbar(true)
+
+	for _, line := range strings.Split(model.View(), "\n")[3:5] {
+		assert.True(t, strings.HasSuffix(line, scrollbarTrack), "Expected only track in %q", line)
+	}
+}
+
+func TestViewportMultilineRows(t *testing.T) {
+	rows := []Row{}
+
+	for i := 0; i < 5; i++ {
+		rows = append(rows, NewRow(RowData{"text": fmt.Sprintf("row %d", i)}))
+	}
+
+	// Every other row takes up two lines
+	rows[1].Data["text"] = "row 1 tall"
+	rows[3].Data["text"] = "row 3 tall"
+
+	model := New([]Column{
+		NewColumn("text", "Text", 6),
+	}).WithRows(rows).WithMultiline(true).WithMaxHeight(8).Focused(true)
+
+	start, end := model.VisibleIndices()
+	assert.Equal(t, 0, start)
+	assert.Equal(t, 2, end, "First three rows take up four lines")
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	start, end = model.VisibleIndices()
+	assert.Equal(t, 2, start, "Needs to drop two rows to fit the tall row")
+	assert.Equal(t, 4, end)
+}
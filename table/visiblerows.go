@@ -0,0 +1,437 @@
+package table
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// visibleRowChanges tracks what has changed since the visible rows were last
+// cached, so that the cache can be brought up to date without filtering and
+// sorting every row again.
+type visibleRowChanges struct {
+	// If false, the cache must be rebuilt from scratch
+	incremental bool
+
+	// The source rows are the same rows in the same order as when the cache was
+	// built, so the cached rows can still be shown while new ones are filtered
+	// and sorted in the background
+	rowsUnchanged bool
+
+	// The filters changed in a way that can only hide rows, so only the cached
+	// rows need to be checked against them again
+	narrowed bool
+
+	// How many rows at the end of the source rows were added since the cache
+	// was built and still need to be filtered and merged in
+	appended int
+}
+
+// markVisibleRowsOutdated marks the cached visible rows as out of date, so that
+// any rows being filtered and sorted in the background from before now are
+// thrown away when they arrive.
+func (m *Model) markVisibleRowsOutdated() {
+	m.visibleRowCacheUpdated = false
+	m.visibleRowsVersion++
+}
+
+// invalidateVisibleRows marks the visible rows as out of date so that they're
+// rebuilt from scratch the next time they're needed.
+func (m *Model) invalidateVisibleRows() {
+	m.markVisibleRowsOutdated()
+	m.visibleRowChanges = visibleRowChanges{}
+	m.cancelAsyncFilterSort()
+}
+
+// invalidateFilterAndSort marks the visible rows as out of date after the
+// filters or sort order changed in a way that needs every row to be filtered
+// and sorted again, but the rows themselves did not change.
+func (m *Model) invalidateFilterAndSort() {
+	rowsUnchanged := m.visibleRowCacheUpdated || m.visibleRowChanges.rowsUnchanged
+
+	m.markVisibleRowsOutdated()
+	m.visibleRowChanges = visibleRowChanges{
+		rowsUnchanged: rowsUnchanged,
+	}
+}
+
+// beginVisibleRowsChange marks the visible rows as out of date, returning true
+// if the cache can still be updated from what changed instead of being rebuilt.
+func (m *Model) beginVisibleRowsChange() bool {
+	if m.dataSource != nil {
+		m.invalidateVisibleRows()
+
+		return false
+	}
+
+	if m.visibleRowCacheUpdated {
+		m.visibleRowChanges = visibleRowChanges{
+			incremental:   true,
+			rowsUnchanged: true,
+		}
+	}
+
+	m.markVisibleRowsOutdated()
+
+	return m.visibleRowChanges.incremental
+}
+
+// invalidateSelectedRows marks the visible rows as out of date after only the
+// selection of some rows changed, which can't change which rows are visible or
+// their order.
+func (m *Model) invalidateSelectedRows() {
+	m.beginVisibleRowsChange()
+
+	// The rows are still shown until new ones arrive, so show the change now
+	if m.isWaitingForVisibleRows() {
+		m.refreshWaitingVisibleRows()
+	}
+}
+
+// invalidateFilteredRows marks the visible rows as out of date after the filter
+// text or a column filter changed.  If the new filters can only match rows that
+// the cached filters matched, such as after typing another character, only the
+// cached rows are checked again.
+func (m *Model) invalidateFilteredRows() {
+	if !m.beginVisibleRowsChange() || !m.isFilterNarrowed() {
+		m.invalidateFilterAndSort()
+
+		return
+	}
+
+	m.visibleRowChanges.narrowed = true
+}
+
+// invalidateAppendedRows marks the visible rows as out of date after rows were
+// added to the end of the source rows, possibly dropping the oldest rows.
+func (m *Model) invalidateAppendedRows(numAppended int, numRows int) {
+	if !m.beginVisibleRowsChange() {
+		m.invalidateVisibleRows()
+
+		return
+	}
+
+	m.visibleRowChanges.rowsUnchanged = false
+	m.visibleRowChanges.appended = min(m.visibleRowChanges.appended+numAppended, numRows)
+	m.cancelAsyncFilterSort()
+}
+
+// isFilterNarrowed returns true if every row matched by the current filters
+// was also matched by the filters that the cached rows were built with.
+func (m *Model) isFilterNarrowed() bool {
+	if m.filterFunc != nil {
+		return false
+	}
+
+	if !isFilterTextNarrowed(m.columns, m.filterMode, m.visibleRowCacheFilterText, m.filterText()) {
+		return false
+	}
+
+	cachedValues := m.visibleRowCacheColumnFilterValues
+
+	for columnKey, value := range m.columnFilterValues {
+		if !areFilterTermsImplied(parseColumnFilter(columnKey, cachedValues[columnKey]), parseColumnFilter(columnKey, value)) {
+			return false
+		}
+	}
+
+	for columnKey, cachedValue := range cachedValues {
+		if _, exists := m.columnFilterValues[columnKey]; !exists && cachedValue != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isFilterTextNarrowed(columns []Column, mode FilterMode, cached, current string) bool {
+	if cached == current || cached == "" {
+		return true
+	}
+
+	switch mode {
+	case FilterModeSubstring:
+		cachedTerms, err := parseFilter(columns, cached)
+
+		// An invalid filter matched nothing, so anything else could match more
+		if err != nil {
+			return false
+		}
+
+		currentTerms, err := parseFilter(columns, current)
+
+		// An invalid filter matches nothing
+		if err != nil {
+			return true
+		}
+
+		return areFilterTermsImplied(cachedTerms, currentTerms)
+
+	case FilterModeFuzzy:
+		return areFuzzyTermsImplied(parseFuzzyFilter(cached), parseFuzzyFilter(current))
+
+	default:
+		return false
+	}
+}
+
+// areFilterTermsImplied returns true if any row that matches all of the current
+// terms must also match all of the cached terms.
+func areFilterTermsImplied(cached, current []filterTerm) bool {
+	for _, cachedTerm := range cached {
+		// Empty terms are ignored when filtering
+		if cachedTerm.value == "" {
+			continue
+		}
+
+		implied := false
+
+		for _, currentTerm := range current {
+			if isFilterTermImplied(cachedTerm, currentTerm) {
+				implied = true
+
+				break
+			}
+		}
+
+		if !implied {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isFilterTermImplied returns true if any data matched by the current term must
+// also be matched by the cached term.
+func isFilterTermImplied(cached, current filterTerm) bool {
+	if cached == current {
+		return true
+	}
+
+	if cached.operator != filterOperatorContains || current.operator != filterOperatorContains {
+		return false
+	}
+
+	if cached.negated || current.negated {
+		return false
+	}
+
+	if cached.columnKey != "" && cached.columnKey != current.columnKey {
+		return false
+	}
+
+	return strings.Contains(current.value, cached.value)
+}
+
+// areFuzzyTermsImplied returns true if any row that matches all of the current
+// fuzzy terms must also match all of the cached terms, which is the case if
+// each cached term is a subsequence of some current term.
+func areFuzzyTermsImplied(cached, current [][]rune) bool {
+	for _, cachedTerm := range cached {
+		implied := false
+
+		for _, currentTerm := range current {
+			if isRuneSubsequence(cachedTerm, currentTerm) {
+				implied = true
+
+				break
+			}
+		}
+
+		if !implied {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isRuneSubsequence(sub, runes []rune) bool {
+	i := 0
+
+	for _, r := range runes {
+		if i < len(sub) && sub[i] == r {
+			i++
+		}
+	}
+
+	return i == len(sub)
+}
+
+// The number of rows to filter at a time between checks for whether the
+// filtering has been cancelled
+const filterChunkSize = 10000
+
+// buildVisibleRows filters and sorts the source rows, or updates the cached
+// visible rows if possible.  Returns the rows along with where each of them
+// came from in the source rows.  If the context is cancelled, the error from
+// the context is returned and the rows should be ignored.
+func (m *Model) buildVisibleRows(ctx context.Context) ([]Row, []int, error) {
+	var rows []Row
+
+	if m.canUpdateVisibleRowsIncrementally() {
+		rows = m.updateVisibleRows()
+	} else {
+		sourceRows := m.sourceRows()
+		rows = make([]Row, len(sourceRows))
+		copy(rows, sourceRows)
+
+		for i := range rows {
+			rows[i].sourceIndex = i
+		}
+		if m.filtered {
+			rows = m.getFilteredRowsInChunks(ctx, rows)
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		rows = getSortedRows(m.columns, m.sortOrder, m.sortOptions, rows)
+		rows = m.sortRowsByFilterScore(rows)
+	}
+
+	// Keep track of where the rows came from so that changes can be written
+	// back, but don't leak the index out in the rows themselves
+	sourceIndices := make([]int, len(rows))
+
+	for i := range rows {
+		sourceIndices[i] = rows[i].sourceIndex
+		rows[i].sourceIndex = 0
+		rows[i].filterScore = 0
+	}
+
+	return rows, sourceIndices, ctx.Err()
+}
+
+// getFilteredRowsInChunks filters the rows a chunk at a time, stopping early if
+// the context is cancelled.
+func (m *Model) getFilteredRowsInChunks(ctx context.Context, rows []Row) []Row {
+	// Can't be cancelled, so don't bother splitting the rows up
+	if ctx.Done() == nil {
+		return m.getFilteredRows(rows)
+	}
+
+	filteredRows := make([]Row, 0)
+
+	for start := 0; start < len(rows); start += filterChunkSize {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		end := min(start+filterChunkSize, len(rows))
+
+		filteredRows = append(filteredRows, m.getFilteredRows(rows[start:end])...)
+	}
+
+	return filteredRows
+}
+
+// setVisibleRowCache caches the visible rows, remembering what they were built
+// from so that the cache can be updated later instead of rebuilt.
+func (m *Model) setVisibleRowCache(rows []Row, sourceIndices []int) {
+	m.visibleRowCache = rows
+	m.visibleRowSourceIndexCache = sourceIndices
+	m.visibleRowCacheUpdated = true
+	m.visibleRowChanges = visibleRowChanges{}
+
+	m.visibleRowCacheSourceCount = len(m.rows)
+	m.visibleRowCacheFilterText = m.filterText()
+	m.visibleRowCacheColumnFilterValues = m.columnFilterValues
+}
+
+// canUpdateVisibleRowsIncrementally returns true if the visible rows can be
+// updated from the changes since they were cached.
+func (m *Model) canUpdateVisibleRowsIncrementally() bool {
+	if !m.visibleRowChanges.incremental || m.dataSource != nil {
+		return false
+	}
+
+	// Sorting by filter score reorders everything, so there's no order to
+	// merge into
+	return !m.isSortedByFilterScore()
+}
+
+// updateVisibleRows brings the cached visible rows up to date with the changes
+// since they were cached.  The rows are read again from the source rows so that
+// any changes to their selection are picked up.  Returns the rows with their
+// source indices set.
+func (m *Model) updateVisibleRows() []Row {
+	sourceRows := m.sourceRows()
+	changes := m.visibleRowChanges
+
+	// Rows that were kept from before are at the start of the source rows, and
+	// any rows dropped from the front shift everything else down
+	numKept := len(sourceRows) - changes.appended
+	dropped := m.visibleRowCacheSourceCount - numKept
+
+	rows := make([]Row, 0, len(m.visibleRowSourceIndexCache)+changes.appended)
+
+	for _, cachedIndex := range m.visibleRowSourceIndexCache {
+		index := cachedIndex - dropped
+
+		if index < 0 {
+			continue
+		}
+
+		row := sourceRows[index]
+		row.sourceIndex = index
+		rows = append(rows, row)
+	}
+
+	if changes.narrowed {
+		rows = m.getFilteredRows(rows)
+	}
+
+	if changes.appended == 0 {
+		return rows
+	}
+
+	appended := make([]Row, changes.appended)
+	copy(appended, sourceRows[numKept:])
+
+	for i := range appended {
+		appended[i].sourceIndex = numKept + i
+	}
+
+	appended = m.getFilteredRows(appended)
+	appended = getSortedRows(m.columns, m.sortOrder, m.sortOptions, appended)
+
+	return mergeSortedRows(m.columns, m.sortOrder, m.sortOptions, rows, appended)
+}
+
+// mergeSortedRows merges rows that are already sorted into other rows that are
+// already sorted, as if they had all been sorted together.  Every added row
+// must have come after every existing row in the source rows, so that added
+// rows come after existing rows that are equal to them.
+func mergeSortedRows(columns []Column, sortOrder []SortColumn, options sortOptions, rows []Row, added []Row) []Row {
+	merged := make([]Row, 0, len(rows)+len(added))
+
+	if len(sortOrder) == 0 {
+		merged = append(merged, rows...)
+
+		return append(merged, added...)
+	}
+
+	sorted := newSortableTable(columns, sortOrder, options, added)
+	start := 0
+
+	for addedIndex, row := range added {
+		addedKeys := sorted.rowKeys(addedIndex)
+
+		// Find the first existing row that the added row should come before
+		position := start + sort.Search(len(rows)-start, func(i int) bool {
+			existingKeys := sorted.readKeys(rows[start+i])
+
+			return sorted.lessKeys(addedKeys, existingKeys)
+		})
+
+		merged = append(merged, rows[start:position]...)
+		merged = append(merged, row)
+
+		start = position
+	}
+
+	return append(merged, rows[start:]...)
+}
@@ -0,0 +1,217 @@
+package table
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+// visibleRowsTestModel returns a model that counts how many times rows are
+// compared by the score column.
+func visibleRowsTestModel(compareCount *int) Model {
+	byScore := func(a, b interface{}) int {
+		*compareCount++
+
+		return a.(int) - b.(int)
+	}
+
+	rows := []Row{}
+
+	for i := 0; i < 20; i++ {
+		rows = append(rows, NewRow(RowData{
+			"name":  fmt.Sprintf("item %d", i),
+			"type":  []string{"fire", "water", "grass"}[i%3],
+			"score": (i * 7) % 10,
+		}))
+	}
+
+	return New([]Column{
+		NewColumn("name", "Name", 10).WithFiltered(true),
+		NewColumn("type", "Type", 10).WithFiltered(true),
+		NewColumn("score", "Score", 5).WithSortFunc(byScore),
+	}).WithRows(rows).
+		Filtered(true).
+		SelectableRows(true).
+		Focused(true).
+		SortByAsc("score")
+}
+
+// rebuiltVisibleRows returns the visible rows as if they had been filtered
+// and sorted from scratch.
+func rebuiltVisibleRows(model Model) []Row {
+	model.invalidateVisibleRows()
+
+	return model.GetVisibleRows()
+}
+
+func TestVisibleRowsSelectionDoesNotSort(t *testing.T) {
+	compareCount := 0
+	model := visibleRowsTestModel(&compareCount)
+
+	model.GetVisibleRows()
+	compareCount = 0
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	assert.True(t, model.GetVisibleRows()[0].selected)
+	assert.Equal(t, 0, compareCount, "Should not sort again")
+	assert.Equal(t, rebuiltVisibleRows(model), model.GetVisibleRows())
+}
+
+func TestVisibleRowsNarrowedFilterDoesNotSort(t *testing.T) {
+	compareCount := 0
+	model := visibleRowsTestModel(&compareCount).WithFilterInputValue("fi")
+
+	model.GetVisibleRows()
+	compareCount = 0
+
+	model = model.WithFilterInputValue("fire 1")
+
+	assert.True(t, model.visibleRowChanges.narrowed)
+	assert.Len(t, model.GetVisibleRows(), 3)
+	assert.Equal(t, 0, compareCount, "Should not sort again")
+	assert.Equal(t, rebuiltVisibleRows(model), model.GetVisibleRows())
+}
+
+func TestVisibleRowsWidenedFilterRebuilds(t *testing.T) {
+	compareCount := 0
+	model := visibleRowsTestModel(&compareCount).WithFilterInputValue("fire")
+
+	assert.Len(t, model.GetVisibleRows(), 7)
+
+	model = model.WithFilterInputValue("fir")
+
+	assert.False(t, model.visibleRowChanges.incremental)
+	assert.Len(t, model.GetVisibleRows(), 7)
+
+	model = model.WithFilterInputValue("")
+
+	assert.Len(t, model.GetVisibleRows(), 20)
+}
+
+func TestVisibleRowsAppendMergesIntoSortedRows(t *testing.T) {
+	compareCount := 0
+	model := visibleRowsTestModel(&compareCount).WithFilterInputValue("-grass")
+
+	model.GetVisibleRows()
+	compareCount = 0
+
+	model = model.AppendRows(
+		NewRow(RowData{"name": "new 1", "type": "fire", "score": 5}),
+		NewRow(RowData{"name": "new 2", "type": "grass", "score": 0}),
+		NewRow(RowData{"name": "new 3", "type": "water", "score": 0}),
+	)
+
+	mergeCount := compareCount
+	compareCount = 0
+
+	assert.Equal(t, rebuiltVisibleRows(model), model.GetVisibleRows())
+	assert.Less(t, mergeCount, compareCount, "Should compare fewer rows than sorting everything")
+}
+
+func TestFilterTermsImplied(t *testing.T) {
+	columns := []Column{
+		NewColumn("name", "Name", 10).WithFiltered(true),
+		NewColumn("hp", "HP", 5).WithFiltered(true),
+	}
+
+	tests := []struct {
+		cached   string
+		current  string
+		expected bool
+	}{
+		{"fi", "fir", true},
+		{"fi", "fi re", true},
+		{"fi", "xfix", true},
+		{"fi", "name:fire", true},
+		{"name:fi", "fire", false},
+		{"fir", "fi", false},
+		{"-fi", "-fir", false},
+		{"-fi", "-fi x", true},
+		{"hp>5", "hp>50", false},
+		{"hp>5", "hp>5 fire", true},
+		{`"a b"`, `"a bc"`, true},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s to %s", test.cached, test.current), func(t *testing.T) {
+			cachedTerms, err := parseFilter(columns, test.cached)
+			assert.NoError(t, err)
+
+			currentTerms, err := parseFilter(columns, test.current)
+			assert.NoError(t, err)
+
+			assert.Equal(t, test.expected, areFilterTermsImplied(cachedTerms, currentTerms))
+		})
+	}
+}
+
+func TestFuzzyTermsImplied(t *testing.T) {
+	assert.True(t, areFuzzyTermsImplied(parseFuzzyFilter("fr"), parseFuzzyFilter("fir")))
+	assert.True(t, areFuzzyTermsImplied(parseFuzzyFilter("fr"), parseFuzzyFilter("x fire")))
+	assert.False(t, areFuzzyTermsImplied(parseFuzzyFilter("rf"), parseFuzzyFilter("fire")))
+}
+
+// Any sequence of changes should end up with the same visible rows as
+// filtering and sorting everything from scratch.
+func TestVisibleRowsIncrementalMatchesRebuild(t *testing.T) {
+	compareCount := 0
+
+	//nolint:gosec // Doesn't need to be secure, just repeatable
+	random := rand.New(rand.NewSource(7))
+
+	filters := []string{"", "i", "it", "ite", "item", "item 1", "f", "fi", "fire", "-fire", "-fire w", "water"}
+
+	for run := 0; run < 20; run++ {
+		model := visibleRowsTestModel(&compareCount).WithMaxRows(25).WithColumnFilterRow(true)
+		model.GetVisibleRows()
+
+		for step := 0; step < 30; step++ {
+			switch random.Intn(5) {
+			case 0:
+				model = model.WithFilterInputValue(filters[random.Intn(len(filters))])
+
+			case 1:
+				model = model.WithColumnFilterValue("name", filters[random.Intn(len(filters))])
+
+			case 2:
+				model = model.AppendRows(NewRow(RowData{
+					"name":  fmt.Sprintf("new %d", step),
+					"type":  []string{"fire", "water"}[random.Intn(2)],
+					"score": random.Intn(10),
+				}))
+
+			case 3:
+				if model.TotalRows() > 0 {
+					model = model.WithHighlightedRow(random.Intn(model.TotalRows()))
+					model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+				}
+
+			case 4:
+				if random.Intn(2) == 0 {
+					model = model.WithFilterMode(FilterModeFuzzy)
+				} else {
+					model = model.WithFilterMode(FilterModeSubstring)
+				}
+			}
+
+			// Sometimes let several changes pile up before checking
+			if random.Intn(3) == 0 {
+				continue
+			}
+
+			assert.Equal(t, rebuiltVisibleRows(model), model.GetVisibleRows(), "Run %d step %d", run, step)
+			assert.Equal(t, rebuiltSourceIndices(model), model.visibleRowSourceIndexCache, "Run %d step %d", run, step)
+		}
+	}
+}
+
+func rebuiltSourceIndices(model Model) []int {
+	model.invalidateVisibleRows()
+	model.GetVisibleRows()
+
+	return model.visibleRowSourceIndexCache
+}